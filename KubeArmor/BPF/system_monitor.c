@@ -24,6 +24,11 @@
 #include <linux/pid_namespace.h>
 #include <linux/proc_ns.h>
 
+#include <linux/bpf.h>
+#include <linux/fdtable.h>
+#include <linux/fcntl.h>
+#include <linux/in6.h>
+#include <linux/socket.h>
 #include <linux/un.h>
 #include <net/inet_sock.h>
 
@@ -36,6 +41,9 @@
 #define MAX_BUFFER_SIZE   32768
 #define MAX_STRING_SIZE   4096
 #define MAX_STR_ARR_ELEM  20
+#define MAX_DNS_SIZE      512
+
+#define DNS_PORT          53
 
 #define NONE_T        0UL
 #define INT_T         1UL
@@ -46,6 +54,10 @@
 #define EXEC_FLAGS_T  14UL
 #define SOCK_DOM_T    15UL
 #define SOCK_TYPE_T   16UL
+#define DNS_T         19UL
+#define CLONE_FLAGS_T 20UL
+#define PTRACE_REQ_T  21UL
+#define MOUNT_FLAGS_T 22UL
 
 #define MAX_ARGS               6
 #define ENC_ARG_TYPE(n, type)  type<<(8*n)
@@ -62,11 +74,21 @@ enum {
     _SYS_OPEN = 2,
     _SYS_OPENAT = 257,
     _SYS_CLOSE = 3,
+    _SYS_MEMFD_CREATE = 319,
+    _SYS_DUP = 32,
+    _SYS_DUP2 = 33,
+    _SYS_DUP3 = 292,
+    _SYS_FCNTL = 72,
 
     // network
     _SYS_SOCKET = 41,
     _SYS_CONNECT = 42,
     _SYS_ACCEPT = 43,
+    _SYS_SENDTO = 44,
+    _SYS_RECVFROM = 45,
+    _SYS_SENDMSG = 46,
+    _SYS_RECVMSG = 47,
+    _SYS_SENDMMSG = 307,
     _SYS_BIND = 49,
     _SYS_LISTEN = 50,
 
@@ -74,6 +96,25 @@ enum {
     _SYS_EXECVE = 59,
     _SYS_EXECVEAT = 322,
     _DO_EXIT = 351,
+    _DO_FORK = 352,
+
+    // kernel
+    _SYS_INIT_MODULE = 175,
+    _SYS_DELETE_MODULE = 176,
+    _SYS_FINIT_MODULE = 313,
+    _SYS_BPF = 321,
+
+    // privilege
+    _SYS_SETUID = 105,
+    _SYS_SETGID = 106,
+    _SYS_SETREUID = 113,
+    _SYS_SETREGID = 114,
+    _SYS_SETRESUID = 117,
+    _SYS_SETRESGID = 119,
+    _SYS_SETNS = 308,
+    _SYS_UNSHARE = 272,
+    _SYS_MOUNT = 165,
+    _SYS_PTRACE = 101,
 };
 
 typedef struct __attribute__((__packed__)) sys_context {
@@ -82,6 +123,8 @@ typedef struct __attribute__((__packed__)) sys_context {
     u32 pid_id;
     u32 mnt_id;
 
+    u64 cgroup_id;
+
     u32 host_ppid;
     u32 host_pid;
 
@@ -98,6 +141,16 @@ typedef struct __attribute__((__packed__)) sys_context {
 
 BPF_HASH(pid_ns_map, u32, u32);
 
+typedef struct ns_key {
+    u32 pid_ns;
+    u32 mnt_ns;
+} ns_key_t;
+
+BPF_HASH(ns_ignore_map, ns_key_t, u32);
+
+// cgroup ids of the containers tracked by KubeArmor (updated by the system monitor)
+BPF_HASH(cgroup_map, u64, u32);
+
 typedef struct args {
     unsigned long args[6];
 } args_t;
@@ -170,6 +223,20 @@ static __always_inline u32 get_task_ppid(struct task_struct *task)
     return task->real_parent->pid;
 }
 
+// == Cgroup Management == //
+
+static __always_inline u32 is_tracked_cgroup()
+{
+#if LINUX_VERSION_CODE >= KERNEL_VERSION(4, 18, 0)
+    u64 cgroup_id = bpf_get_current_cgroup_id();
+    if (cgroup_map.lookup(&cgroup_id) != 0) {
+        return 1;
+    }
+#endif
+
+    return 0;
+}
+
 // == Pid NS Management == //
 
 static __always_inline u32 add_pid_ns()
@@ -216,7 +283,8 @@ static __always_inline u32 add_pid_ns()
 
     u32 pid_ns = get_task_pid_ns_id(task);
     if (pid_ns == PROC_PID_INIT_INO) {
-        return 0;
+        // containers sharing the host pid namespace (hostPID) are tracked by their cgroup ids
+        return is_tracked_cgroup();
     }
 
     if (pid_ns_map.lookup(&pid_ns) != 0) {
@@ -299,6 +367,11 @@ static __always_inline u32 skip_syscall()
 
 #elif defined(MONITOR_HOST_AND_CONTAINER)
 
+    // containers are tracked by their cgroup ids first, and then by their pid namespaces
+    if (is_tracked_cgroup()) {
+        return 0;
+    }
+
     u32 pid_ns = get_task_pid_ns_id(task);
     if (pid_ns == PROC_PID_INIT_INO) { // host
         u32 pid = bpf_get_current_pid_tgid() >> 32;
@@ -314,6 +387,11 @@ static __always_inline u32 skip_syscall()
 
 #else /* !MONITOR_HOST */
 
+    // containers are tracked by their cgroup ids first (even with hostPID), and then by their pid namespaces
+    if (is_tracked_cgroup()) {
+        return 0;
+    }
+
     u32 pid_ns = get_task_pid_ns_id(task);
     if (pid_ns_map.lookup(&pid_ns) != 0) {
         return 0;
@@ -324,6 +402,22 @@ static __always_inline u32 skip_syscall()
     return 1;
 }
 
+static __always_inline u32 is_ignored_ns()
+{
+    struct task_struct *task = (struct task_struct *)bpf_get_current_task();
+
+    ns_key_t key = {};
+
+    key.pid_ns = get_task_pid_ns_id(task);
+    key.mnt_ns = get_task_mnt_ns_id(task);
+
+    if (ns_ignore_map.lookup(&key) != 0) {
+        return 1;
+    }
+
+    return 0;
+}
+
 // == Context Management == //
 
 static __always_inline u32 init_context(sys_context_t *context)
@@ -372,6 +466,12 @@ static __always_inline u32 init_context(sys_context_t *context)
 
     context->uid = bpf_get_current_uid_gid();
 
+#if LINUX_VERSION_CODE >= KERNEL_VERSION(4, 18, 0)
+    context->cgroup_id = bpf_get_current_cgroup_id();
+#else
+    context->cgroup_id = 0;
+#endif
+
     bpf_get_current_comm(&context->comm, sizeof(context->comm));
 
     return 0;
@@ -535,7 +635,12 @@ static __always_inline int save_args_to_buffer(u64 types, args_t *args)
             save_to_buffer(bufs_p, (void*)&(args->args[i]), sizeof(int), OPEN_FLAGS_T);
             break;
         case STR_T:
-            save_str_to_buffer(bufs_p, (void *)args->args[i]);
+            if (args->args[i]) {
+                save_str_to_buffer(bufs_p, (void *)args->args[i]);
+            } else {
+                char empty[1] = "";
+                save_str_to_buffer(bufs_p, (void *)empty);
+            }
             break;
         case SOCK_DOM_T:
             save_to_buffer(bufs_p, (void*)&(args->args[i]), sizeof(int), SOCK_DOM_T);
@@ -543,6 +648,15 @@ static __always_inline int save_args_to_buffer(u64 types, args_t *args)
         case SOCK_TYPE_T:
             save_to_buffer(bufs_p, (void*)&(args->args[i]), sizeof(int), SOCK_TYPE_T);
             break;
+        case CLONE_FLAGS_T:
+            save_to_buffer(bufs_p, (void*)&(args->args[i]), sizeof(int), CLONE_FLAGS_T);
+            break;
+        case PTRACE_REQ_T:
+            save_to_buffer(bufs_p, (void*)&(args->args[i]), sizeof(int), PTRACE_REQ_T);
+            break;
+        case MOUNT_FLAGS_T:
+            save_to_buffer(bufs_p, (void*)&(args->args[i]), sizeof(int), MOUNT_FLAGS_T);
+            break;
         case SOCKADDR_T:
             if (args->args[i]) {
                 short family = 0;
@@ -570,6 +684,9 @@ static __always_inline int save_args_to_buffer(u64 types, args_t *args)
 
 static __always_inline int events_perf_submit(struct pt_regs *ctx)
 {
+    if (is_ignored_ns())
+        return 0;
+
     bufs_t *bufs_p = get_buffer();
     if (bufs_p == NULL)
         return -1;
@@ -586,6 +703,23 @@ static __always_inline int events_perf_submit(struct pt_regs *ctx)
 
 // == Syscall Hooks (Process) == //
 
+// fileless executions are backed by files without any links (memfd or deleted binaries)
+static __always_inline const unsigned char* get_fileless_exec_name()
+{
+    struct task_struct *task = (struct task_struct *)bpf_get_current_task();
+    struct dentry *dentry = task->mm->exe_file->f_path.dentry;
+
+    if (dentry == NULL) {
+        return NULL;
+    }
+
+    if (dentry->d_inode->i_nlink != 0) {
+        return NULL;
+    }
+
+    return dentry->d_name.name;
+}
+
 int syscall__execve(struct pt_regs *ctx,
     const char __user *filename,
     const char __user *const __user *__argv,
@@ -636,6 +770,15 @@ int trace_ret_execve(struct pt_regs *ctx)
         return 0;
     }
 
+    const unsigned char *fileless = NULL;
+
+    if (context.retval == 0) {
+        fileless = get_fileless_exec_name();
+        if (fileless != NULL) {
+            context.argnum = 1;
+        }
+    }
+
     set_buffer_offset(sizeof(sys_context_t));
 
     bufs_t *bufs_p = get_buffer();
@@ -644,6 +787,10 @@ int trace_ret_execve(struct pt_regs *ctx)
 
     save_context_to_buffer(bufs_p, (void*)&context);
 
+    if (fileless != NULL) {
+        save_str_to_buffer(bufs_p, (void *)fileless);
+    }
+
     events_perf_submit(ctx);
 
     return 0;
@@ -703,6 +850,15 @@ int trace_ret_execveat(struct pt_regs *ctx)
         return 0;
     }
 
+    const unsigned char *fileless = NULL;
+
+    if (context.retval == 0) {
+        fileless = get_fileless_exec_name();
+        if (fileless != NULL) {
+            context.argnum = 1;
+        }
+    }
+
     set_buffer_offset(sizeof(sys_context_t));
 
     bufs_t *bufs_p = get_buffer();
@@ -711,6 +867,10 @@ int trace_ret_execveat(struct pt_regs *ctx)
 
     save_context_to_buffer(bufs_p, (void*)&context);
 
+    if (fileless != NULL) {
+        save_str_to_buffer(bufs_p, (void *)fileless);
+    }
+
     events_perf_submit(ctx);
 
     return 0;
@@ -720,6 +880,11 @@ int trace_do_exit(struct pt_regs *ctx, long code)
 {
     sys_context_t context = {};
 
+    // only the exits of thread group leaders are reported (threads share pids and fds)
+    u64 pid_tgid = bpf_get_current_pid_tgid();
+    if ((u32)pid_tgid != (u32)(pid_tgid >> 32))
+        return 0;
+
     if (skip_syscall())
         return 0;
 
@@ -744,6 +909,37 @@ int trace_do_exit(struct pt_regs *ctx, long code)
     return 0;
 }
 
+int trace_wake_up_new_task(struct pt_regs *ctx, struct task_struct *task)
+{
+    sys_context_t context = {};
+
+    // only new processes are reported (threads share the fds of their thread groups)
+    u32 child_tgid = task->tgid;
+    if (child_tgid == bpf_get_current_pid_tgid() >> 32)
+        return 0;
+
+    if (skip_syscall())
+        return 0;
+
+    init_context(&context);
+
+    context.event_id = _DO_FORK;
+    context.argnum = 0;
+    context.retval = child_tgid;
+
+    set_buffer_offset(sizeof(sys_context_t));
+
+    bufs_t *bufs_p = get_buffer();
+    if (bufs_p == NULL)
+        return 0;
+
+    save_context_to_buffer(bufs_p, (void*)&context);
+
+    events_perf_submit(ctx);
+
+    return 0;
+}
+
 // == Syscall Hooks (File) == //
 
 static __always_inline int save_args(u32 event_id, struct pt_regs *ctx)
@@ -885,6 +1081,98 @@ int trace_ret_close(struct pt_regs *ctx)
     return trace_ret_generic(_SYS_CLOSE, ctx, ARG_TYPE0(INT_T));
 }
 
+int syscall__memfd_create(struct pt_regs *ctx)
+{
+    return save_args(_SYS_MEMFD_CREATE, ctx);
+}
+
+int trace_ret_memfd_create(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_MEMFD_CREATE, ctx, ARG_TYPE0(STR_T)|ARG_TYPE1(INT_T));
+}
+
+int syscall__dup(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_DUP, ctx);
+}
+
+int trace_ret_dup(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_DUP, ctx, ARG_TYPE0(INT_T));
+}
+
+int syscall__dup2(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_DUP2, ctx);
+}
+
+int trace_ret_dup2(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_DUP2, ctx, ARG_TYPE0(INT_T)|ARG_TYPE1(INT_T));
+}
+
+int syscall__dup3(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_DUP3, ctx);
+}
+
+int trace_ret_dup3(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_DUP3, ctx, ARG_TYPE0(INT_T)|ARG_TYPE1(INT_T));
+}
+
+int syscall__fcntl(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_FCNTL, ctx);
+}
+
+int trace_ret_fcntl(struct pt_regs *ctx)
+{
+    sys_context_t context = {};
+    args_t args = {};
+
+    if (load_args(_SYS_FCNTL, &args) != 0)
+        return 0;
+
+    if (skip_syscall())
+        return 0;
+
+    // only fd duplications are reported (fd, cmd)
+    if ((int)args.args[1] != F_DUPFD && (int)args.args[1] != F_DUPFD_CLOEXEC)
+        return 0;
+
+    init_context(&context);
+
+    context.event_id = _SYS_FCNTL;
+    context.argnum = 2;
+    context.retval = PT_REGS_RC(ctx);
+
+    set_buffer_offset(sizeof(sys_context_t));
+
+    bufs_t *bufs_p = get_buffer();
+    if (bufs_p == NULL)
+        return 0;
+
+    save_context_to_buffer(bufs_p, (void*)&context);
+    save_args_to_buffer(ARG_TYPE0(INT_T)|ARG_TYPE1(INT_T), &args);
+
+    events_perf_submit(ctx);
+
+    return 0;
+}
+
 // == Syscall Hooks (Network) == //
 
 int syscall__socket(struct pt_regs *ctx)
@@ -951,3 +1239,467 @@ int trace_ret_listen(struct pt_regs *ctx)
 {
     return trace_ret_generic(_SYS_LISTEN, ctx, ARG_TYPE0(INT_T)|ARG_TYPE1(INT_T));
 }
+
+// == Syscall Hooks (DNS) == //
+
+static __always_inline u16 get_sockaddr_port(void *addr)
+{
+    short family = 0;
+    bpf_probe_read(&family, sizeof(short), addr);
+
+    if (family == AF_INET) {
+        struct sockaddr_in sin = {};
+        bpf_probe_read(&sin, sizeof(struct sockaddr_in), addr);
+        return ntohs(sin.sin_port);
+    } else if (family == AF_INET6) {
+        struct sockaddr_in6 sin6 = {};
+        bpf_probe_read(&sin6, sizeof(struct sockaddr_in6), addr);
+        return ntohs(sin6.sin6_port);
+    }
+
+    return 0;
+}
+
+static __always_inline u16 get_sock_dport(int fd)
+{
+    struct task_struct *task = (struct task_struct *)bpf_get_current_task();
+    struct file **fds = task->files->fdt->fd;
+
+    struct file *f = NULL;
+    bpf_probe_read(&f, sizeof(f), &fds[fd]);
+    if (f == NULL) {
+        return 0;
+    }
+
+    struct socket *sock = f->private_data;
+    if (sock == NULL) {
+        return 0;
+    }
+
+    return ntohs(sock->sk->__sk_common.skc_dport);
+}
+
+static __always_inline int save_dns_to_buffer(bufs_t *bufs_p, void *ptr, int len)
+{
+    u32 *off = get_buffer_offset();
+    if (off == NULL) {
+        return -1;
+    }
+
+    if (*off > MAX_BUFFER_SIZE - MAX_DNS_SIZE - sizeof(int) - 1) {
+        return 0; // not enough space - return
+    }
+
+    u8 type = DNS_T;
+    bpf_probe_read(&(bufs_p->buf[*off & (MAX_BUFFER_SIZE-1)]), 1, &type);
+
+    *off += 1;
+
+    if (len > MAX_DNS_SIZE - 1) {
+        len = MAX_DNS_SIZE - 1;
+    }
+    len &= (MAX_DNS_SIZE - 1);
+
+    if (*off > MAX_BUFFER_SIZE - MAX_DNS_SIZE - sizeof(int)) {
+        return 0;
+    }
+
+    if (bpf_probe_read(&(bufs_p->buf[*off + sizeof(int)]), len, ptr) != 0) {
+        len = 0;
+    }
+
+    bpf_probe_read(&(bufs_p->buf[*off]), sizeof(int), &len);
+
+    *off += len + sizeof(int);
+    set_buffer_offset(*off);
+
+    return len + sizeof(int);
+}
+
+static __always_inline int trace_ret_dns(u32 id, struct pt_regs *ctx)
+{
+    sys_context_t context = {};
+    args_t args = {};
+
+    if (load_args(id, &args) != 0)
+        return 0;
+
+    if (skip_syscall())
+        return 0;
+
+    long retval = PT_REGS_RC(ctx);
+    if (retval <= 0) {
+        return 0;
+    }
+
+    void *buf = NULL;
+    void *addr = NULL;
+
+    if (id == _SYS_SENDTO || id == _SYS_RECVFROM) {
+        // fd, buf, len, flags, addr, addrlen
+        buf = (void *)args.args[1];
+        addr = (void *)args.args[4];
+    } else if (id == _SYS_SENDMMSG) {
+        // fd, mmsghdr, vlen, flags (resolvers send A and AAAA queries together, and only the first message is reported)
+        struct mmsghdr mmsg = {};
+        bpf_probe_read(&mmsg, sizeof(mmsg), (void *)args.args[1]);
+
+        struct iovec iov = {};
+        bpf_probe_read(&iov, sizeof(iov), (void *)mmsg.msg_hdr.msg_iov);
+
+        buf = iov.iov_base;
+        addr = mmsg.msg_hdr.msg_name;
+
+        // the return value is the number of messages sent, and msg_len is the size of the first message
+        retval = mmsg.msg_len;
+        if (retval > iov.iov_len) {
+            retval = iov.iov_len;
+        }
+    } else {
+        // fd, msghdr, flags
+        struct user_msghdr msg = {};
+        bpf_probe_read(&msg, sizeof(msg), (void *)args.args[1]);
+
+        struct iovec iov = {};
+        bpf_probe_read(&iov, sizeof(iov), (void *)msg.msg_iov);
+
+        buf = iov.iov_base;
+        addr = msg.msg_name;
+
+        if (retval > iov.iov_len) {
+            retval = iov.iov_len;
+        }
+    }
+
+    // only DNS queries and responses (port 53) are reported
+
+    u16 port = 0;
+
+    if (addr != NULL) {
+        port = get_sockaddr_port(addr);
+    } else {
+        port = get_sock_dport((int)args.args[0]);
+    }
+
+    if (port != DNS_PORT) {
+        return 0;
+    }
+
+    init_context(&context);
+
+    context.event_id = id;
+    context.argnum = (addr != NULL) ? 3 : 2;
+    context.retval = retval;
+
+    set_buffer_offset(sizeof(sys_context_t));
+
+    bufs_t *bufs_p = get_buffer();
+    if (bufs_p == NULL)
+        return 0;
+
+    save_context_to_buffer(bufs_p, (void*)&context);
+
+    save_to_buffer(bufs_p, (void*)&(args.args[0]), sizeof(int), INT_T);
+    save_dns_to_buffer(bufs_p, buf, (int)retval);
+
+    if (addr != NULL) {
+        args.args[1] = (unsigned long)addr;
+        save_args_to_buffer(ARG_TYPE1(SOCKADDR_T), &args);
+    }
+
+    events_perf_submit(ctx);
+
+    return 0;
+}
+
+int syscall__sendto(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_SENDTO, ctx);
+}
+
+int trace_ret_sendto(struct pt_regs *ctx)
+{
+    return trace_ret_dns(_SYS_SENDTO, ctx);
+}
+
+int syscall__recvfrom(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_RECVFROM, ctx);
+}
+
+int trace_ret_recvfrom(struct pt_regs *ctx)
+{
+    return trace_ret_dns(_SYS_RECVFROM, ctx);
+}
+
+int syscall__sendmsg(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_SENDMSG, ctx);
+}
+
+int trace_ret_sendmsg(struct pt_regs *ctx)
+{
+    return trace_ret_dns(_SYS_SENDMSG, ctx);
+}
+
+int syscall__recvmsg(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_RECVMSG, ctx);
+}
+
+int trace_ret_recvmsg(struct pt_regs *ctx)
+{
+    return trace_ret_dns(_SYS_RECVMSG, ctx);
+}
+
+int syscall__sendmmsg(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_SENDMMSG, ctx);
+}
+
+int trace_ret_sendmmsg(struct pt_regs *ctx)
+{
+    return trace_ret_dns(_SYS_SENDMMSG, ctx);
+}
+
+// == Syscall Hooks (Kernel) == //
+
+int syscall__init_module(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_INIT_MODULE, ctx);
+}
+
+int trace_ret_init_module(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_INIT_MODULE, ctx, ARG_TYPE1(INT_T)|ARG_TYPE2(STR_T));
+}
+
+int syscall__finit_module(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_FINIT_MODULE, ctx);
+}
+
+int trace_ret_finit_module(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_FINIT_MODULE, ctx, ARG_TYPE0(INT_T)|ARG_TYPE1(STR_T)|ARG_TYPE2(INT_T));
+}
+
+int syscall__delete_module(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_DELETE_MODULE, ctx);
+}
+
+int trace_ret_delete_module(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_DELETE_MODULE, ctx, ARG_TYPE0(STR_T)|ARG_TYPE1(INT_T));
+}
+
+int syscall__bpf(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_BPF, ctx);
+}
+
+int trace_ret_bpf(struct pt_regs *ctx)
+{
+    sys_context_t context = {};
+    args_t args = {};
+
+    if (load_args(_SYS_BPF, &args) != 0)
+        return 0;
+
+    if (skip_syscall())
+        return 0;
+
+    // only program loads are reported (cmd, attr, size)
+    if ((int)args.args[0] != BPF_PROG_LOAD)
+        return 0;
+
+    init_context(&context);
+
+    context.event_id = _SYS_BPF;
+    context.argnum = 2;
+    context.retval = PT_REGS_RC(ctx);
+
+    set_buffer_offset(sizeof(sys_context_t));
+
+    bufs_t *bufs_p = get_buffer();
+    if (bufs_p == NULL)
+        return 0;
+
+    save_context_to_buffer(bufs_p, (void*)&context);
+
+    union bpf_attr *attr = (union bpf_attr *)args.args[1];
+
+    u32 prog_type = 0;
+    bpf_probe_read(&prog_type, sizeof(prog_type), &attr->prog_type);
+    save_to_buffer(bufs_p, (void*)&prog_type, sizeof(int), INT_T);
+
+    char prog_name[16] = {};
+#if LINUX_VERSION_CODE >= KERNEL_VERSION(4, 15, 0)
+    bpf_probe_read(&prog_name, sizeof(prog_name), &attr->prog_name);
+#endif
+    save_str_to_buffer(bufs_p, (void *)prog_name);
+
+    events_perf_submit(ctx);
+
+    return 0;
+}
+
+// == Syscall Hooks (Privilege) == //
+
+int syscall__setuid(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_SETUID, ctx);
+}
+
+int trace_ret_setuid(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_SETUID, ctx, ARG_TYPE0(INT_T));
+}
+
+int syscall__setgid(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_SETGID, ctx);
+}
+
+int trace_ret_setgid(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_SETGID, ctx, ARG_TYPE0(INT_T));
+}
+
+int syscall__setreuid(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_SETREUID, ctx);
+}
+
+int trace_ret_setreuid(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_SETREUID, ctx, ARG_TYPE0(INT_T)|ARG_TYPE1(INT_T));
+}
+
+int syscall__setregid(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_SETREGID, ctx);
+}
+
+int trace_ret_setregid(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_SETREGID, ctx, ARG_TYPE0(INT_T)|ARG_TYPE1(INT_T));
+}
+
+int syscall__setresuid(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_SETRESUID, ctx);
+}
+
+int trace_ret_setresuid(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_SETRESUID, ctx, ARG_TYPE0(INT_T)|ARG_TYPE1(INT_T)|ARG_TYPE2(INT_T));
+}
+
+int syscall__setresgid(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_SETRESGID, ctx);
+}
+
+int trace_ret_setresgid(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_SETRESGID, ctx, ARG_TYPE0(INT_T)|ARG_TYPE1(INT_T)|ARG_TYPE2(INT_T));
+}
+
+int syscall__setns(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_SETNS, ctx);
+}
+
+int trace_ret_setns(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_SETNS, ctx, ARG_TYPE0(INT_T)|ARG_TYPE1(CLONE_FLAGS_T));
+}
+
+int syscall__unshare(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_UNSHARE, ctx);
+}
+
+int trace_ret_unshare(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_UNSHARE, ctx, ARG_TYPE0(CLONE_FLAGS_T));
+}
+
+int syscall__mount(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_MOUNT, ctx);
+}
+
+int trace_ret_mount(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_MOUNT, ctx, ARG_TYPE0(STR_T)|ARG_TYPE1(STR_T)|ARG_TYPE2(STR_T)|ARG_TYPE3(MOUNT_FLAGS_T));
+}
+
+int syscall__ptrace(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_PTRACE, ctx);
+}
+
+int trace_ret_ptrace(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_PTRACE, ctx, ARG_TYPE0(PTRACE_REQ_T)|ARG_TYPE1(INT_T));
+}
@@ -6,6 +6,7 @@ package common
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"os"
 	"os/exec"
@@ -13,6 +14,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	kg "github.com/kubearmor/KubeArmor/KubeArmor/log"
@@ -216,6 +218,133 @@ func GetHostName() string {
 	return ""
 }
 
+// ============ //
+// == Cgroup == //
+// ============ //
+
+// CgroupRoots for the unified hierarchy (cgroup v2) of the host (/proc/1/root works even without the mount of /sys/fs/cgroup)
+var CgroupRoots = []string{"/proc/1/root/sys/fs/cgroup", "/proc/1/root/sys/fs/cgroup/unified", "/sys/fs/cgroup", "/sys/fs/cgroup/unified"}
+
+// cgroupNsRoot is the path of the root of KubeArmor's cgroup namespace in the hierarchy of the host
+var cgroupNsRoot string
+var cgroupNsRootOnce sync.Once
+
+// getCgroupPath Function
+func getCgroupPath(pid string) string {
+	data, err := ioutil.ReadFile(filepath.Clean("/proc/" + pid + "/cgroup"))
+	if err != nil {
+		return ""
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		// the unified hierarchy (cgroup v2), which bpf_get_current_cgroup_id() refers to
+		if strings.HasPrefix(line, "0::") {
+			return strings.TrimPrefix(line, "0::")
+		}
+	}
+
+	return ""
+}
+
+// findCgroupOfPid Function
+func findCgroupOfPid(root, dir, pid string) string {
+	if procs, err := ioutil.ReadFile(filepath.Clean(filepath.Join(root, dir, "cgroup.procs"))); err == nil {
+		for _, val := range strings.Fields(string(procs)) {
+			if val == pid {
+				return dir
+			}
+		}
+	}
+
+	files, err := ioutil.ReadDir(filepath.Join(root, dir))
+	if err != nil {
+		return ""
+	}
+
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+
+		if found := findCgroupOfPid(root, filepath.Join(dir, file.Name()), pid); found != "" {
+			return found
+		}
+	}
+
+	return ""
+}
+
+// getCgroupNsRoot Function
+func getCgroupNsRoot() string {
+	cgroupNsRootOnce.Do(func() {
+		// no cgroup namespace (old kernels) or the same cgroup namespace as the host
+		self, err := os.Readlink("/proc/self/ns/cgroup")
+		if err != nil {
+			cgroupNsRoot = "/"
+			return
+		}
+
+		if host, err := os.Readlink("/proc/1/ns/cgroup"); err == nil && self == host {
+			cgroupNsRoot = "/"
+			return
+		}
+
+		// in a private cgroup namespace, the root of the namespace is the cgroup that KubeArmor was in when it started
+		// (cgroup.procs shows the pids in KubeArmor's pid namespace, so they are compared with its own pid)
+		for _, root := range CgroupRoots {
+			if found := findCgroupOfPid(root, "/", strconv.Itoa(os.Getpid())); found != "" {
+				cgroupNsRoot = found
+				return
+			}
+		}
+	})
+
+	return cgroupNsRoot
+}
+
+// GetCgroupDir Function
+func GetCgroupDir(pid string) string {
+	path := getCgroupPath(pid)
+	if path == "" {
+		return ""
+	}
+
+	// paths are relative to the cgroup namespace of KubeArmor (e.g., /../../kubepods/...) if it has a private one
+	if nsRoot := getCgroupNsRoot(); nsRoot == "" {
+		return ""
+	} else if nsRoot != "/" {
+		path = filepath.Join(nsRoot, path)
+	}
+
+	// the root cgroup is shared by all the processes not in any container
+	if filepath.Clean(path) == "/" {
+		return ""
+	}
+
+	for _, root := range CgroupRoots {
+		if info, err := os.Stat(filepath.Join(root, path)); err == nil && info.IsDir() {
+			return filepath.Join(root, path)
+		}
+	}
+
+	return ""
+}
+
+// GetCgroupID Function
+func GetCgroupID(pid string) uint64 {
+	dir := GetCgroupDir(pid)
+	if dir == "" {
+		return 0
+	}
+
+	var stat unix.Stat_t
+	if err := unix.Stat(dir, &stat); err != nil {
+		return 0
+	}
+
+	return stat.Ino
+}
+
 // ================= //
 // == File Output == //
 // ================= //
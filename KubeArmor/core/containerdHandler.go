@@ -175,6 +175,9 @@ func (ch *ContainerdHandler) GetContainerInfo(ctx context.Context, containerID s
 				fmt.Printf("Failed to get MntNS (%s, %s, %s)\n", containerID, pid, err.Error())
 			}
 		}
+
+		container.CgroupID = kl.GetCgroupID(pid)
+		container.CgroupDir = kl.GetCgroupDir(pid)
 	} else {
 		return container, err
 	}
@@ -282,7 +285,10 @@ func (dm *KubeArmorDaemon) UpdateContainerdContainer(ctx context.Context, contai
 		dm.ContainersLock.Unlock()
 
 		// update NsMap
-		dm.SystemMonitor.AddContainerIDToNsMap(containerID, container.PidNS, container.MntNS)
+		dm.SystemMonitor.AddContainerIDToNsMap(containerID, container.NamespaceName, container.CgroupID, container.PidNS, container.MntNS)
+
+		// update the process tree with the processes already running
+		dm.SystemMonitor.SeedActivePids(containerID, container.CgroupDir, container.PidNS, container.MntNS)
 
 		dm.LogFeeder.Printf("Detected a container (added/%s)", containerID[:12])
 
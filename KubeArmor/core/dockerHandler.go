@@ -145,6 +145,9 @@ func (dh *DockerHandler) GetContainerInfo(containerID string) (tp.Container, err
 		}
 	}
 
+	container.CgroupID = kl.GetCgroupID(pid)
+	container.CgroupDir = kl.GetCgroupDir(pid)
+
 	// == //
 
 	return container, nil
@@ -215,7 +218,10 @@ func (dm *KubeArmorDaemon) GetAlreadyDeployedDockerContainers() {
 				dm.ContainersLock.Unlock()
 
 				// update NsMap
-				dm.SystemMonitor.AddContainerIDToNsMap(container.ContainerID, container.PidNS, container.MntNS)
+				dm.SystemMonitor.AddContainerIDToNsMap(container.ContainerID, container.NamespaceName, container.CgroupID, container.PidNS, container.MntNS)
+
+				// update the process tree with the processes already running
+				dm.SystemMonitor.SeedActivePids(container.ContainerID, container.CgroupDir, container.PidNS, container.MntNS)
 
 				dm.LogFeeder.Printf("Detected a container (added/%s)", container.ContainerID[:12])
 			}
@@ -272,7 +278,10 @@ func (dm *KubeArmorDaemon) UpdateDockerContainer(containerID, action string) {
 		dm.ContainersLock.Unlock()
 
 		// update NsMap
-		dm.SystemMonitor.AddContainerIDToNsMap(containerID, container.PidNS, container.MntNS)
+		dm.SystemMonitor.AddContainerIDToNsMap(containerID, container.NamespaceName, container.CgroupID, container.PidNS, container.MntNS)
+
+		// update the process tree with the processes already running
+		dm.SystemMonitor.SeedActivePids(containerID, container.CgroupDir, container.PidNS, container.MntNS)
 
 		dm.LogFeeder.Printf("Detected a container (added/%s)", containerID[:12])
 
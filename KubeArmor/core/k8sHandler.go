@@ -14,9 +14,14 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
@@ -42,7 +47,7 @@ func init() {
 
 // K8sHandler Structure
 type K8sHandler struct {
-	K8sClient   *kubernetes.Clientset
+	K8sClient   kubernetes.Interface
 	HTTPClient  *http.Client
 	WatchClient *http.Client
 
@@ -262,68 +267,337 @@ func (kh *K8sHandler) GetNodeIdentities() []string {
 	return nodeIdentities
 }
 
-// ================ //
-// == Deployment == //
-// ================ //
+// =============== //
+// == Namespace == //
+// =============== //
+
+// GetNamespaceLabels Function
+func (kh *K8sHandler) GetNamespaceLabels(namespaceName string) []string {
+	namespaceLabels := []string{}
 
-// PatchDeploymentWithAppArmorAnnotations Function
-func (kh *K8sHandler) PatchDeploymentWithAppArmorAnnotations(namespaceName, deploymentName string, appArmorAnnotations map[string]string) error {
+	if !kl.IsK8sEnv() { // not Kubernetes
+		return namespaceLabels
+	}
+
+	// get a namespace from k8s api client
+	namespace, err := kh.K8sClient.CoreV1().Namespaces().Get(context.Background(), namespaceName, metav1.GetOptions{})
+	if err != nil {
+		return namespaceLabels
+	}
+
+	// add labels
+	for k, v := range namespace.ObjectMeta.Labels {
+		namespaceLabels = append(namespaceLabels, k+"="+v)
+	}
+
+	return namespaceLabels
+}
+
+// WatchK8sNamespaces Function
+func (kh *K8sHandler) WatchK8sNamespaces() *http.Response {
 	if !kl.IsK8sEnv() { // not Kubernetes
 		return nil
 	}
 
-	spec := `{"spec":{"template":{"metadata":{"annotations":{"kubearmor-policy":"enabled",`
+	if kl.IsInK8sCluster() { // kube-apiserver
+		URL := "https://" + kh.K8sHost + ":" + kh.K8sPort + "/api/v1/namespaces?watch=true"
+
+		req, err := http.NewRequest("GET", URL, nil)
+		if err != nil {
+			return nil
+		}
+
+		req.Header.Add("Content-Type", "application/json")
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", kh.K8sToken))
+
+		resp, err := kh.WatchClient.Do(req)
+		if err != nil {
+			return nil
+		}
+
+		return resp
+	}
+
+	// kube-proxy (local)
+	URL := "http://" + kh.K8sHost + ":" + kh.K8sPort + "/api/v1/namespaces?watch=true"
+
+	// #nosec
+	if resp, err := http.Get(URL); err == nil {
+		return resp
+	}
+
+	return nil
+}
+
+// ============== //
+// == Workload == //
+// ============== //
+
+// PatchWorkloadTemplate Function
+func (kh *K8sHandler) PatchWorkloadTemplate(namespaceName, workloadKind, workloadName, template string) error {
+	spec := `{"spec":{"template":` + template + `}}`
+
+	var err error
+
+	switch workloadKind {
+	case "Deployment":
+		_, err = kh.K8sClient.AppsV1().Deployments(namespaceName).Patch(context.Background(), workloadName, types.StrategicMergePatchType, []byte(spec), metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = kh.K8sClient.AppsV1().StatefulSets(namespaceName).Patch(context.Background(), workloadName, types.StrategicMergePatchType, []byte(spec), metav1.PatchOptions{})
+	case "DaemonSet":
+		_, err = kh.K8sClient.AppsV1().DaemonSets(namespaceName).Patch(context.Background(), workloadName, types.StrategicMergePatchType, []byte(spec), metav1.PatchOptions{})
+	case "CronJob":
+		// the pod template of a cronjob is in its job template
+		spec = `{"spec":{"jobTemplate":{"spec":{"template":` + template + `}}}}`
+		_, err = kh.K8sClient.BatchV1().CronJobs(namespaceName).Patch(context.Background(), workloadName, types.StrategicMergePatchType, []byte(spec), metav1.PatchOptions{})
+	default:
+		err = fmt.Errorf("unsupported workload kind (%s)", workloadKind)
+	}
+
+	return err
+}
+
+// PatchWorkloadWithAppArmorAnnotations Function
+func (kh *K8sHandler) PatchWorkloadWithAppArmorAnnotations(namespaceName, workloadKind, workloadName string, appArmorAnnotations map[string]string) error {
+	if !kl.IsK8sEnv() { // not Kubernetes
+		return nil
+	}
+
+	// the kubearmor-policy annotation is not added, so that the pods keep following the annotation of their namespace
+	template := `{"metadata":{"annotations":{`
 	count := len(appArmorAnnotations)
 
 	for k, v := range appArmorAnnotations {
-		spec = spec + `"container.apparmor.security.beta.kubernetes.io/` + k + `":"localhost/` + v + `"`
+		template = template + `"container.apparmor.security.beta.kubernetes.io/` + k + `":"localhost/` + v + `"`
 
 		if count > 1 {
-			spec = spec + ","
+			template = template + ","
 		}
 
 		count--
 	}
 
-	spec = spec + `}}}}}`
+	template = template + `}}}`
 
-	_, err := kh.K8sClient.AppsV1().Deployments(namespaceName).Patch(context.Background(), deploymentName, types.StrategicMergePatchType, []byte(spec), metav1.PatchOptions{})
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return kh.PatchWorkloadTemplate(namespaceName, workloadKind, workloadName, template)
 }
 
-// PatchDeploymentWithSELinuxOptions Function
-func (kh *K8sHandler) PatchDeploymentWithSELinuxOptions(namespace, deploymentName string, seLinuxContexts map[string]string) error {
+// PatchWorkloadWithSELinuxOptions Function
+func (kh *K8sHandler) PatchWorkloadWithSELinuxOptions(namespaceName, workloadKind, workloadName string, seLinuxContexts map[string]string) error {
 	if !kl.IsK8sEnv() { // not Kubernetes
 		return nil
 	}
 
-	spec := `{"spec":{"template":{"metadata":{"annotations":{"kubearmor-policy":"enabled"}},"spec":{"containers":[`
+	template := `{"spec":{"containers":[`
 	count := len(seLinuxContexts)
 
 	for _, v := range seLinuxContexts {
-		spec = spec + v
+		template = template + v
 
 		if count > 1 {
-			spec = spec + ","
+			template = template + ","
 		}
 
 		count--
 	}
 
-	spec = spec + `]}}}}`
+	template = template + `]}}`
 
-	_, err := kh.K8sClient.AppsV1().Deployments(namespace).Patch(context.Background(), deploymentName, types.StrategicMergePatchType, []byte(spec), metav1.PatchOptions{})
+	return kh.PatchWorkloadTemplate(namespaceName, workloadKind, workloadName, template)
+}
+
+// GetWorkloadControllingPod Function
+func (kh *K8sHandler) GetWorkloadControllingPod(namespaceName string, ownerReferences []metav1.OwnerReference) (string, string) {
+	if !kl.IsK8sEnv() { // not Kubernetes
+		return "", ""
+	}
+
+	// check if we have ownerReferences
+	if len(ownerReferences) == 0 {
+		return "", ""
+	}
+
+	switch ownerReferences[0].Kind {
+	case "ReplicaSet":
+		if deploymentName := kh.GetDeploymentNameControllingReplicaSet(namespaceName, ownerReferences[0].Name); deploymentName != "" {
+			return "Deployment", deploymentName
+		}
+	case "StatefulSet", "DaemonSet":
+		return ownerReferences[0].Kind, ownerReferences[0].Name
+	case "Job":
+		// the pod template of a job is immutable, so only jobs created by cronjobs can be patched
+		if cronJobName := kh.GetCronJobNameControllingJob(namespaceName, ownerReferences[0].Name); cronJobName != "" {
+			return "CronJob", cronJobName
+		}
+	}
+
+	return "", ""
+}
+
+// ================ //
+// == Quarantine == //
+// ================ //
+
+// QuarantineLabel is added to quarantined pods
+const QuarantineLabel = "kubearmor-quarantine"
+
+// QuarantineNetworkPolicy is the name of the deny-all network policy for quarantined pods
+const QuarantineNetworkPolicy = "kubearmor-quarantine"
+
+// LabelPodForQuarantine Function
+func (kh *K8sHandler) LabelPodForQuarantine(namespaceName, podName string) error {
+	if kh.K8sClient == nil {
+		return fmt.Errorf("no kubernetes client")
+	}
+
+	patch := `{"metadata":{"labels":{"` + QuarantineLabel + `":"true"}}}`
+
+	_, err := kh.K8sClient.CoreV1().Pods(namespaceName).Patch(context.Background(), podName, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	return err
+}
+
+// IsolatePod Function
+func (kh *K8sHandler) IsolatePod(namespaceName, podName string) error {
+	// the network policy selects the pods with the quarantine label
+	if err := kh.LabelPodForQuarantine(namespaceName, podName); err != nil {
+		return err
+	}
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      QuarantineNetworkPolicy,
+			Namespace: namespaceName,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{QuarantineLabel: "true"},
+			},
+			// no ingress and egress rules, deny all
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+		},
+	}
+
+	if _, err := kh.K8sClient.NetworkingV1().NetworkPolicies(namespaceName).Create(context.Background(), networkPolicy, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	return nil
+}
+
+// GetEvictionVersion Function
+func (kh *K8sHandler) GetEvictionVersion() string {
+	// policy/v1 evictions are served from Kubernetes 1.22, and policy/v1beta1 ones are removed in Kubernetes 1.25
+	resources, err := kh.K8sClient.Discovery().ServerResourcesForGroupVersion("v1")
 	if err != nil {
+		return "v1beta1"
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Name == "pods/eviction" && resource.Group == "policy" && resource.Version == "v1" {
+			return "v1"
+		}
+	}
+
+	return "v1beta1"
+}
+
+// EvictPod Function
+func (kh *K8sHandler) EvictPod(namespaceName, podName string) error {
+	if kh.K8sClient == nil {
+		return fmt.Errorf("no kubernetes client")
+	}
+
+	var err error
+
+	if kh.GetEvictionVersion() == "v1" {
+		// client-go v0.21 has no policy/v1 eviction, so the eviction is posted as it is
+		eviction := `{"apiVersion":"policy/v1","kind":"Eviction","metadata":{"name":"` + podName + `","namespace":"` + namespaceName + `"}}`
+		err = kh.K8sClient.CoreV1().RESTClient().Post().Namespace(namespaceName).Resource("pods").Name(podName).SubResource("eviction").Body([]byte(eviction)).Do(context.Background()).Error()
+	} else {
+		eviction := &policyv1beta1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podName,
+				Namespace: namespaceName,
+			},
+		}
+		err = kh.K8sClient.CoreV1().Pods(namespaceName).Evict(context.Background(), eviction)
+	}
+
+	if err != nil && !apierrors.IsNotFound(err) {
 		return err
 	}
 
 	return nil
 }
 
+// ============ //
+// == Events == //
+// ============ //
+
+// CreateViolationEvent Function
+func (kh *K8sHandler) CreateViolationEvent(violation tp.K8sViolationEvent) (string, error) {
+	if kh.K8sClient == nil {
+		return "", fmt.Errorf("no kubernetes client")
+	}
+
+	// kubectl describe finds the events of a pod by its UID
+	pod, err := kh.K8sClient.CoreV1().Pods(violation.NamespaceName).Get(context.Background(), violation.PodName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	event := &v1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      violation.PodName + "." + strconv.FormatInt(time.Now().UnixNano(), 16),
+			Namespace: violation.NamespaceName,
+		},
+		InvolvedObject: v1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  violation.NamespaceName,
+			Name:       violation.PodName,
+			UID:        pod.UID,
+		},
+		Reason:         "PolicyViolation",
+		Message:        violation.Message,
+		Type:           violation.Type,
+		Count:          violation.Count,
+		FirstTimestamp: metav1.NewTime(violation.FirstTime),
+		LastTimestamp:  metav1.NewTime(violation.LastTime),
+		Source: v1.EventSource{
+			Component: "kubearmor",
+			Host:      kl.GetHostName(),
+		},
+	}
+
+	created, err := kh.K8sClient.CoreV1().Events(violation.NamespaceName).Create(context.Background(), event, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return created.Name, nil
+}
+
+// UpdateViolationEvent Function
+func (kh *K8sHandler) UpdateViolationEvent(violation tp.K8sViolationEvent) error {
+	if kh.K8sClient == nil {
+		return fmt.Errorf("no kubernetes client")
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"type":          violation.Type,
+		"message":       violation.Message,
+		"count":         violation.Count,
+		"lastTimestamp": metav1.NewTime(violation.LastTime),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = kh.K8sClient.CoreV1().Events(violation.NamespaceName).Patch(context.Background(), violation.EventName, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
 // ================ //
 // == ReplicaSet == //
 // ================ //
@@ -354,6 +628,36 @@ func (kh *K8sHandler) GetDeploymentNameControllingReplicaSet(namespaceName, repl
 	return rs.ObjectMeta.OwnerReferences[0].Name
 }
 
+// ========= //
+// == Job == //
+// ========= //
+
+// GetCronJobNameControllingJob Function
+func (kh *K8sHandler) GetCronJobNameControllingJob(namespaceName, jobName string) string {
+	if !kl.IsK8sEnv() { // not Kubernetes
+		return ""
+	}
+
+	// get job from k8s api client
+	job, err := kh.K8sClient.BatchV1().Jobs(namespaceName).Get(context.Background(), jobName, metav1.GetOptions{})
+	if err != nil {
+		return ""
+	}
+
+	// check if we have ownerReferences
+	if len(job.ObjectMeta.OwnerReferences) == 0 {
+		return ""
+	}
+
+	// check if given ownerReferences are for CronJob
+	if job.ObjectMeta.OwnerReferences[0].Kind != "CronJob" {
+		return ""
+	}
+
+	// return the cronjob name
+	return job.ObjectMeta.OwnerReferences[0].Name
+}
+
 // ========== //
 // == Pods == //
 // ========== //
@@ -369,6 +673,21 @@ func (kh *K8sHandler) GetK8sPod(K8sPods []tp.K8sPod, namespaceName, endPointName
 	return tp.K8sPod{}
 }
 
+// GetK8sPods Function
+func (kh *K8sHandler) GetK8sPods(namespaceName string) []v1.Pod {
+	if !kl.IsK8sEnv() { // not Kubernetes
+		return []v1.Pod{}
+	}
+
+	// get the pods in a namespace from k8s api client
+	pods, err := kh.K8sClient.CoreV1().Pods(namespaceName).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return []v1.Pod{}
+	}
+
+	return pods.Items
+}
+
 // WatchK8sPods Function
 func (kh *K8sHandler) WatchK8sPods() *http.Response {
 	if !kl.IsK8sEnv() { // not Kubernetes
@@ -485,6 +804,42 @@ func (kh *K8sHandler) WatchK8sSecurityPolicies() *http.Response {
 	return nil
 }
 
+// WatchK8sClusterSecurityPolicies Function
+func (kh *K8sHandler) WatchK8sClusterSecurityPolicies() *http.Response {
+	if !kl.IsK8sEnv() { // not Kubernetes
+		return nil
+	}
+
+	if kl.IsInK8sCluster() {
+		URL := "https://" + kh.K8sHost + ":" + kh.K8sPort + "/apis/security.kubearmor.com/v1/kubearmorclusterpolicies?watch=true"
+
+		req, err := http.NewRequest("GET", URL, nil)
+		if err != nil {
+			return nil
+		}
+
+		req.Header.Add("Content-Type", "application/json")
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", kh.K8sToken))
+
+		resp, err := kh.WatchClient.Do(req)
+		if err != nil {
+			return nil
+		}
+
+		return resp
+	}
+
+	// kube-proxy (local)
+	URL := "http://" + kh.K8sHost + ":" + kh.K8sPort + "/apis/security.kubearmor.com/v1/kubearmorclusterpolicies?watch=true"
+
+	// #nosec
+	if resp, err := http.Get(URL); err == nil {
+		return resp
+	}
+
+	return nil
+}
+
 // WatchK8sHostSecurityPolicies Function
 func (kh *K8sHandler) WatchK8sHostSecurityPolicies() *http.Response {
 	if !kl.IsK8sEnv() { // not Kubernetes
@@ -0,0 +1,195 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+func TestQuarantine(t *testing.T) {
+	// create K8sHandler with a fake client
+	kh := NewK8sHandler()
+	kh.K8sClient = fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ubuntu-1",
+			Namespace: "multiubuntu",
+		},
+	})
+
+	// label the pod
+	if err := kh.LabelPodForQuarantine("multiubuntu", "ubuntu-1"); err != nil {
+		t.Errorf("[FAIL] Failed to label the pod (%s)", err.Error())
+		return
+	}
+
+	pod, err := kh.K8sClient.CoreV1().Pods("multiubuntu").Get(context.Background(), "ubuntu-1", metav1.GetOptions{})
+	if err != nil || pod.Labels[QuarantineLabel] != "true" {
+		t.Error("[FAIL] Failed to find the quarantine label")
+		return
+	}
+
+	t.Log("[PASS] Labeled the pod")
+
+	// isolate the pod twice
+	for i := 0; i < 2; i++ {
+		if err := kh.IsolatePod("multiubuntu", "ubuntu-1"); err != nil {
+			t.Errorf("[FAIL] Failed to isolate the pod (%s)", err.Error())
+			return
+		}
+	}
+
+	networkPolicy, err := kh.K8sClient.NetworkingV1().NetworkPolicies("multiubuntu").Get(context.Background(), QuarantineNetworkPolicy, metav1.GetOptions{})
+	if err != nil {
+		t.Error("[FAIL] Failed to find the network policy")
+		return
+	}
+
+	if networkPolicy.Spec.PodSelector.MatchLabels[QuarantineLabel] != "true" || len(networkPolicy.Spec.Ingress) > 0 || len(networkPolicy.Spec.Egress) > 0 {
+		t.Error("[FAIL] Failed to deny all traffic of the pod")
+		return
+	}
+
+	t.Log("[PASS] Isolated the pod")
+
+	// evict the pod
+	if err := kh.EvictPod("multiubuntu", "ubuntu-1"); err != nil {
+		t.Errorf("[FAIL] Failed to evict the pod (%s)", err.Error())
+		return
+	}
+
+	evicted := false
+	for _, action := range kh.K8sClient.(*fake.Clientset).Actions() {
+		if action.GetVerb() == "create" && action.GetSubresource() == "eviction" {
+			evicted = true
+		}
+	}
+
+	if !evicted {
+		t.Error("[FAIL] Failed to find the eviction")
+		return
+	}
+
+	t.Log("[PASS] Evicted the pod")
+}
+
+func TestViolationEvents(t *testing.T) {
+	// create K8sHandler with a fake client
+	kh := NewK8sHandler()
+	kh.K8sClient = fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ubuntu-1",
+			Namespace: "multiubuntu",
+			UID:       "ubuntu-1-uid",
+		},
+	})
+
+	violation := tp.K8sViolationEvent{
+		NamespaceName: "multiubuntu",
+		PodName:       "ubuntu-1",
+		PolicyName:    "ksp-ubuntu-1-proc-path-block",
+		Type:          "Warning",
+		Message:       GetViolationMessage(tp.Log{PolicyName: "ksp-ubuntu-1-proc-path-block", Operation: "Process", Resource: "/bin/sleep 1", Action: "Block"}),
+		Count:         3,
+		FirstTime:     time.Now(),
+		LastTime:      time.Now(),
+	}
+
+	// create an event
+	eventName, err := kh.CreateViolationEvent(violation)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create an event (%s)", err.Error())
+		return
+	}
+
+	event, err := kh.K8sClient.CoreV1().Events("multiubuntu").Get(context.Background(), eventName, metav1.GetOptions{})
+	if err != nil || event.InvolvedObject.UID != "ubuntu-1-uid" || event.Type != "Warning" || event.Count != 3 {
+		t.Error("[FAIL] Failed to find the event of the pod")
+		return
+	}
+
+	if event.Message != "ksp-ubuntu-1-proc-path-block: Process /bin/sleep 1 (Block)" {
+		t.Errorf("[FAIL] Unexpected message (%s)", event.Message)
+		return
+	}
+
+	t.Log("[PASS] Created an event")
+
+	// update the event
+	violation.EventName = eventName
+	violation.Count = 5
+
+	if err := kh.UpdateViolationEvent(violation); err != nil {
+		t.Errorf("[FAIL] Failed to update the event (%s)", err.Error())
+		return
+	}
+
+	event, err = kh.K8sClient.CoreV1().Events("multiubuntu").Get(context.Background(), eventName, metav1.GetOptions{})
+	if err != nil || event.Count != 5 {
+		t.Error("[FAIL] Failed to update the count of the event")
+		return
+	}
+
+	t.Log("[PASS] Updated the event")
+}
+
+func TestPatchCronJob(t *testing.T) {
+	// create K8sHandler with a fake client
+	kh := NewK8sHandler()
+	kh.K8sClient = fake.NewSimpleClientset(&batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "backup",
+			Namespace: "multiubuntu",
+		},
+	})
+
+	// patch the pod template of the cronjob (batch/v1)
+	template := `{"metadata":{"annotations":{"container.apparmor.security.beta.kubernetes.io/backup":"localhost/kubearmor-multiubuntu-backup"}}}`
+	if err := kh.PatchWorkloadTemplate("multiubuntu", "CronJob", "backup", template); err != nil {
+		t.Errorf("[FAIL] Failed to patch the cronjob (%s)", err.Error())
+		return
+	}
+
+	cronJob, err := kh.K8sClient.BatchV1().CronJobs("multiubuntu").Get(context.Background(), "backup", metav1.GetOptions{})
+	if err != nil || cronJob.Spec.JobTemplate.Spec.Template.Annotations["container.apparmor.security.beta.kubernetes.io/backup"] != "localhost/kubearmor-multiubuntu-backup" {
+		t.Error("[FAIL] Failed to find the annotation in the job template")
+		return
+	}
+
+	t.Log("[PASS] Patched the cronjob")
+}
+
+func TestGetEvictionVersion(t *testing.T) {
+	// create K8sHandler with a fake client (Kubernetes 1.21)
+	kh := NewK8sHandler()
+	client := fake.NewSimpleClientset()
+	client.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{{Name: "pods/eviction", Group: "policy", Version: "v1beta1", Kind: "Eviction"}},
+	}}
+	kh.K8sClient = client
+
+	if version := kh.GetEvictionVersion(); version != "v1beta1" {
+		t.Errorf("[FAIL] Failed to find the eviction version (expected: v1beta1, got: %s)", version)
+		return
+	}
+
+	// Kubernetes 1.22 or later
+	client.Resources[0].APIResources[0].Version = "v1"
+
+	if version := kh.GetEvictionVersion(); version != "v1" {
+		t.Errorf("[FAIL] Failed to find the eviction version (expected: v1, got: %s)", version)
+		return
+	}
+
+	t.Log("[PASS] Found the eviction version served by the API server")
+}
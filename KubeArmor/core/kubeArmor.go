@@ -18,6 +18,8 @@ import (
 	efc "github.com/kubearmor/KubeArmor/KubeArmor/enforcer"
 	fd "github.com/kubearmor/KubeArmor/KubeArmor/feeder"
 	mon "github.com/kubearmor/KubeArmor/KubeArmor/monitor"
+
+	pb "github.com/kubearmor/KubeArmor/protobuf"
 )
 
 // ====================== //
@@ -42,9 +44,20 @@ type KubeArmorDaemon struct {
 	LogPath   string
 	LogFilter string
 
+	// namespaces not to be monitored
+	UntrackedNamespaces []string
+
 	// options
 	EnableHostPolicy     bool
 	EnableEnforcerPerPod bool
+	EnableK8sEvents      bool
+
+	// policy discovery (disabled if the path is empty)
+	PolicyDiscoveryPath     string
+	PolicyDiscoveryInterval time.Duration
+
+	// seccomp profiles of the syscalls traced in pods
+	EnablePartialSeccompProfiles bool
 
 	// containers (from docker)
 	Containers     map[string]tp.Container
@@ -58,6 +71,14 @@ type KubeArmorDaemon struct {
 	K8sPods     []tp.K8sPod
 	K8sPodsLock *sync.RWMutex
 
+	// K8s namespaces
+	K8sNamespaces     map[string]tp.K8sNamespace
+	K8sNamespacesLock *sync.RWMutex
+
+	// namespace name + pod name -> quarantine actions taken
+	QuarantinedPods     map[string][]string
+	QuarantinedPodsLock *sync.Mutex
+
 	// Security policies
 	SecurityPolicies     []tp.SecurityPolicy
 	SecurityPoliciesLock *sync.RWMutex
@@ -89,25 +110,38 @@ type KubeArmorDaemon struct {
 }
 
 // NewKubeArmorDaemon Function
-func NewKubeArmorDaemon(clusterName, gRPCPort, logPath, logFilter string, enableHostPolicy, enableEnforcerPerPod bool) *KubeArmorDaemon {
+func NewKubeArmorDaemon(cfg tp.KubeArmorConfig) *KubeArmorDaemon {
 	dm := new(KubeArmorDaemon)
 
-	if clusterName == "" {
+	if cfg.ClusterName == "" {
 		if val, ok := os.LookupEnv("CLUSTER_NAME"); ok {
 			dm.ClusterName = val
 		} else {
 			dm.ClusterName = "Default"
 		}
 	} else {
-		dm.ClusterName = clusterName
+		dm.ClusterName = cfg.ClusterName
+	}
+
+	dm.gRPCPort = cfg.GRPCPort
+	dm.LogPath = cfg.LogPath
+	dm.LogFilter = cfg.LogFilter
+
+	dm.UntrackedNamespaces = []string{}
+	for _, ns := range strings.Split(cfg.UntrackedNamespaces, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			dm.UntrackedNamespaces = append(dm.UntrackedNamespaces, ns)
+		}
 	}
 
-	dm.gRPCPort = gRPCPort
-	dm.LogPath = logPath
-	dm.LogFilter = logFilter
+	dm.EnableHostPolicy = cfg.EnableHostPolicy
+	dm.EnableEnforcerPerPod = cfg.EnableEnforcerPerPod
+	dm.EnableK8sEvents = cfg.EnableK8sEvents
 
-	dm.EnableHostPolicy = enableHostPolicy
-	dm.EnableEnforcerPerPod = enableEnforcerPerPod
+	dm.PolicyDiscoveryPath = cfg.PolicyDiscoveryPath
+	dm.PolicyDiscoveryInterval = cfg.PolicyDiscoveryInterval
+
+	dm.EnablePartialSeccompProfiles = cfg.EnablePartialSeccompProfiles
 
 	dm.Containers = map[string]tp.Container{}
 	dm.ContainersLock = new(sync.RWMutex)
@@ -118,6 +152,12 @@ func NewKubeArmorDaemon(clusterName, gRPCPort, logPath, logFilter string, enable
 	dm.K8sPods = []tp.K8sPod{}
 	dm.K8sPodsLock = new(sync.RWMutex)
 
+	dm.K8sNamespaces = map[string]tp.K8sNamespace{}
+	dm.K8sNamespacesLock = new(sync.RWMutex)
+
+	dm.QuarantinedPods = map[string][]string{}
+	dm.QuarantinedPodsLock = new(sync.Mutex)
+
 	dm.SecurityPolicies = []tp.SecurityPolicy{}
 	dm.SecurityPoliciesLock = new(sync.RWMutex)
 
@@ -175,7 +215,14 @@ func (dm *KubeArmorDaemon) DestroyKubeArmorDaemon() {
 // InitLogFeeder Function
 func (dm *KubeArmorDaemon) InitLogFeeder() bool {
 	dm.LogFeeder = fd.NewFeeder(dm.ClusterName, dm.gRPCPort, dm.LogPath, dm.LogFilter, dm.EnableHostPolicy)
-	return dm.LogFeeder != nil
+	if dm.LogFeeder == nil {
+		return false
+	}
+
+	// register a process tree service (before serving log feeds)
+	pb.RegisterProcessTreeServiceServer(dm.LogFeeder.LogServer, &ProcessTreeService{Daemon: dm})
+
+	return true
 }
 
 // ServeLogFeeds Function
@@ -222,6 +269,8 @@ func (dm *KubeArmorDaemon) InitSystemMonitor() bool {
 		return false
 	}
 
+	dm.SystemMonitor.UntrackedNamespaces = dm.UntrackedNamespaces
+
 	if err := dm.SystemMonitor.InitBPF(); err != nil {
 		return false
 	}
@@ -238,6 +287,9 @@ func (dm *KubeArmorDaemon) MonitorSystemEvents() {
 
 	if dm.EnableHostPolicy {
 		go dm.SystemMonitor.TraceHostSyscall()
+
+		// update the process tree with the host processes already running
+		dm.SystemMonitor.SeedActiveHostPids()
 	}
 
 	go dm.SystemMonitor.UpdateLogs()
@@ -279,9 +331,9 @@ func GetOSSigChannel() chan os.Signal {
 // ========== //
 
 // KubeArmor Function
-func KubeArmor(clusterName, gRPCPort, logPath, logFilter string, enableHostPolicy, enableEnforcerPerPod bool) {
+func KubeArmor(cfg tp.KubeArmorConfig) {
 	// create a daemon
-	dm := NewKubeArmorDaemon(clusterName, gRPCPort, logPath, logFilter, enableHostPolicy, enableEnforcerPerPod)
+	dm := NewKubeArmorDaemon(cfg)
 
 	// initialize log feeder
 	if !dm.InitLogFeeder() {
@@ -326,14 +378,53 @@ func KubeArmor(clusterName, gRPCPort, logPath, logFilter string, enableHostPolic
 	if K8s.InitK8sClient() {
 		dm.LogFeeder.Print("Initialized the Kubernetes client")
 
+		// watch k8s namespaces
+		go dm.WatchK8sNamespaces()
+		dm.LogFeeder.Print("Started to monitor Namespace events")
+
 		// watch k8s pods
 		go dm.WatchK8sPods()
 		dm.LogFeeder.Print("Started to monitor Pod events")
 
+		// quarantine pods
+		go dm.QuarantinePods()
+		dm.LogFeeder.Print("Started to quarantine pods")
+
+		if dm.EnableK8sEvents {
+			// report policy violations as k8s events
+			dm.LogFeeder.EnableK8sEvents = true
+			go dm.ReportViolationEvents()
+			dm.LogFeeder.Print("Started to report policy violations as Kubernetes events")
+		}
+
+		if dm.PolicyDiscoveryPath != "" {
+			if err := os.MkdirAll(dm.PolicyDiscoveryPath, 0750); err != nil {
+				dm.LogFeeder.Errf("Failed to create %s for policy discovery (%s)", dm.PolicyDiscoveryPath, err.Error())
+			} else if dm.PolicyDiscoveryInterval <= 0 {
+				dm.LogFeeder.Errf("Failed to start policy discovery (invalid interval: %s)", dm.PolicyDiscoveryInterval.String())
+			} else {
+				// discover security policies from the observed behavior of pods
+				dm.LogFeeder.EnablePolicyDiscovery = true
+				go dm.DiscoverSecurityPolicies()
+				dm.LogFeeder.Printf("Started to discover security policies (%s)", dm.PolicyDiscoveryPath)
+			}
+		}
+
+		if dm.EnablePartialSeccompProfiles {
+			// write the syscalls traced in pods as seccomp profiles
+			dm.LogFeeder.EnablePartialSeccompProfiles = true
+			go dm.GeneratePartialSeccompProfiles()
+			dm.LogFeeder.Print("Started to generate partial seccomp profiles")
+		}
+
 		// watch security policies
 		go dm.WatchSecurityPolicies()
 		dm.LogFeeder.Print("Started to monitor security policies")
 
+		// watch cluster security policies
+		go dm.WatchClusterSecurityPolicies()
+		dm.LogFeeder.Print("Started to monitor cluster security policies")
+
 		if dm.EnableHostPolicy {
 			// watch host security policies
 			go dm.WatchHostSecurityPolicies()
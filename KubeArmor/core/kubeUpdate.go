@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"reflect"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 
 	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
+	fd "github.com/kubearmor/KubeArmor/KubeArmor/feeder"
 	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
 )
 
@@ -38,6 +40,7 @@ func (dm *KubeArmorDaemon) UpdateEndPointWithPod(action string, pod tp.K8sPod) {
 		newPoint.Labels = []string{}
 		newPoint.Identities = []string{}
 		newPoint.Containers = []string{}
+		newPoint.ContainerNames = map[string]string{}
 		newPoint.AppArmorProfiles = map[string]string{}
 
 		newPoint.Identities = append(newPoint.Identities, "namespaceName="+pod.Metadata["namespaceName"])
@@ -53,11 +56,15 @@ func (dm *KubeArmorDaemon) UpdateEndPointWithPod(action string, pod tp.K8sPod) {
 			}
 		}
 
+		// update namespace labels
+		newPoint.NamespaceLabels = dm.GetNamespaceLabels(newPoint.NamespaceName)
+
 		// update container list
-		for k := range pod.Containers {
+		for k, v := range pod.Containers {
 			if !kl.ContainsElement(newPoint.Containers, k) {
 				newPoint.Containers = append(newPoint.Containers, k)
 			}
+			newPoint.ContainerNames[k] = v
 		}
 
 		// update flags
@@ -113,12 +120,21 @@ func (dm *KubeArmorDaemon) UpdateEndPointWithPod(action string, pod tp.K8sPod) {
 			}
 		}
 
+		// update seccomp profile names to the endpoint
+		newPoint.SeccompProfiles = map[string]string{}
+		for k, v := range pod.Metadata {
+			if strings.HasPrefix(k, "seccomp-") {
+				contName := strings.Split(k, "seccomp-")[1]
+				newPoint.SeccompProfiles[contName] = v
+			}
+		}
+
 		// update host-side volume mounted
 		newPoint.HostVolumes = []tp.HostVolumeMount{}
 		newPoint.HostVolumes = append(newPoint.HostVolumes, pod.HostVolumes...)
 
 		// update security policies with the identities
-		newPoint.SecurityPolicies = dm.GetSecurityPolicies(newPoint.Identities)
+		newPoint.SecurityPolicies = dm.GetSecurityPolicies(newPoint)
 
 		// add the endpoint into the endpoint list
 		dm.EndPoints = append(dm.EndPoints, newPoint)
@@ -142,6 +158,7 @@ func (dm *KubeArmorDaemon) UpdateEndPointWithPod(action string, pod tp.K8sPod) {
 				dm.EndPoints[idx].Labels = []string{}
 				dm.EndPoints[idx].Identities = []string{}
 				dm.EndPoints[idx].Containers = []string{}
+				dm.EndPoints[idx].ContainerNames = map[string]string{}
 				dm.EndPoints[idx].AppArmorProfiles = map[string]string{}
 
 				dm.EndPoints[idx].Identities = append(dm.EndPoints[idx].Identities, "namespaceName="+pod.Metadata["namespaceName"])
@@ -156,11 +173,15 @@ func (dm *KubeArmorDaemon) UpdateEndPointWithPod(action string, pod tp.K8sPod) {
 					}
 				}
 
+				// update namespace labels
+				dm.EndPoints[idx].NamespaceLabels = dm.GetNamespaceLabels(dm.EndPoints[idx].NamespaceName)
+
 				// update container list
-				for k := range pod.Containers {
+				for k, v := range pod.Containers {
 					if !kl.ContainsElement(dm.EndPoints[idx].Containers, k) {
 						dm.EndPoints[idx].Containers = append(dm.EndPoints[idx].Containers, k)
 					}
+					dm.EndPoints[idx].ContainerNames[k] = v
 				}
 
 				// update flags
@@ -216,13 +237,38 @@ func (dm *KubeArmorDaemon) UpdateEndPointWithPod(action string, pod tp.K8sPod) {
 				}
 				dm.ContainersLock.Unlock()
 
+				// update seccomp profile names to the endpoint
+				dm.EndPoints[idx].SeccompProfiles = map[string]string{}
+				for k, v := range pod.Metadata {
+					if strings.HasPrefix(k, "seccomp-") {
+						contName := strings.Split(k, "seccomp-")[1]
+						dm.EndPoints[idx].SeccompProfiles[contName] = v
+					}
+				}
+
+				// keep the previous selinux profiles to release them when the policy is not enabled anymore
+				if dm.EndPoints[idx].PolicyEnabled == tp.KubeArmorPolicyEnabled {
+					// update selinux profile names to the endpoint
+					dm.EndPoints[idx].SELinuxProfiles = map[string]string{}
+					for k, v := range pod.Metadata {
+						if strings.HasPrefix(k, "selinux-") {
+							contName := strings.Split(k, "selinux-")[1]
+							dm.EndPoints[idx].SELinuxProfiles[contName] = v
+						}
+					}
+
+					// update host-side volume mounted
+					dm.EndPoints[idx].HostVolumes = []tp.HostVolumeMount{}
+					dm.EndPoints[idx].HostVolumes = append(dm.EndPoints[idx].HostVolumes, pod.HostVolumes...)
+				}
+
 				if prevPolicyEnabled != tp.KubeArmorPolicyEnabled && dm.EndPoints[idx].PolicyEnabled == tp.KubeArmorPolicyEnabled {
 					// initialize and register security profiles
 					dm.RuntimeEnforcer.UpdateSecurityProfiles("ADDED", pod, true)
 				}
 
 				// get security policies according to the updated identities
-				dm.EndPoints[idx].SecurityPolicies = dm.GetSecurityPolicies(dm.EndPoints[idx].Identities)
+				dm.EndPoints[idx].SecurityPolicies = dm.GetSecurityPolicies(dm.EndPoints[idx])
 
 				// update security policies
 				dm.LogFeeder.UpdateSecurityPolicies(action, dm.EndPoints[idx])
@@ -245,311 +291,679 @@ func (dm *KubeArmorDaemon) UpdateEndPointWithPod(action string, pod tp.K8sPod) {
 				// remove endpoint
 				dm.EndPoints = append(dm.EndPoints[:idx], dm.EndPoints[idx+1:]...)
 
+				// forget the quarantine actions taken for the pod
+				dm.QuarantinedPodsLock.Lock()
+				delete(dm.QuarantinedPods, pod.Metadata["namespaceName"]+"_"+pod.Metadata["podName"])
+				dm.QuarantinedPodsLock.Unlock()
+
 				break
 			}
 		}
 	}
 }
 
-// WatchK8sPods Function
-func (dm *KubeArmorDaemon) WatchK8sPods() {
-	for {
-		if resp := K8s.WatchK8sPods(); resp != nil {
-			defer resp.Body.Close()
+// GetSeccompProfileName Function
+func GetSeccompProfileName(pod v1.Pod, container v1.Container) string {
+	var seccompProfile *v1.SeccompProfile
 
-			decoder := json.NewDecoder(resp.Body)
-			for {
-				event := tp.K8sPodEvent{}
-				if err := decoder.Decode(&event); err == io.EOF {
-					break
-				} else if err != nil {
-					break
-				}
+	// container-level profiles override the pod-level one
+	if container.SecurityContext != nil && container.SecurityContext.SeccompProfile != nil {
+		seccompProfile = container.SecurityContext.SeccompProfile
+	} else if pod.Spec.SecurityContext != nil && pod.Spec.SecurityContext.SeccompProfile != nil {
+		seccompProfile = pod.Spec.SecurityContext.SeccompProfile
+	}
+
+	if seccompProfile != nil {
+		if seccompProfile.Type == v1.SeccompProfileTypeLocalhost && seccompProfile.LocalhostProfile != nil {
+			return *seccompProfile.LocalhostProfile
+		}
+		return ""
+	}
 
-				// create a pod
+	// annotations (before Kubernetes 1.19)
+	if val, ok := pod.Annotations["container.seccomp.security.alpha.kubernetes.io/"+container.Name]; ok {
+		return strings.TrimPrefix(val, "localhost/")
+	} else if val, ok := pod.Annotations["seccomp.security.alpha.kubernetes.io/pod"]; ok {
+		return strings.TrimPrefix(val, "localhost/")
+	}
 
-				pod := tp.K8sPod{}
+	return ""
+}
 
-				pod.Metadata = map[string]string{}
-				pod.Metadata["namespaceName"] = event.Object.ObjectMeta.Namespace
-				pod.Metadata["podName"] = event.Object.ObjectMeta.Name
+// UpdateK8sPod Function
+func (dm *KubeArmorDaemon) UpdateK8sPod(event tp.K8sPodEvent) {
+	dm.updateK8sPod(event, false)
+}
 
-				if len(event.Object.ObjectMeta.OwnerReferences) > 0 {
-					if event.Object.ObjectMeta.OwnerReferences[0].Kind == "ReplicaSet" {
-						deploymentName := K8s.GetDeploymentNameControllingReplicaSet(pod.Metadata["namespaceName"], event.Object.ObjectMeta.OwnerReferences[0].Name)
-						if deploymentName != "" {
-							pod.Metadata["deploymentName"] = deploymentName
-						}
-					}
+// ReevaluateK8sPod Function
+func (dm *KubeArmorDaemon) ReevaluateK8sPod(event tp.K8sPodEvent) {
+	// the pods patched before are evaluated again with the updated annotations and labels of their namespace
+	dm.updateK8sPod(event, true)
+}
+
+// updateK8sPod Function
+func (dm *KubeArmorDaemon) updateK8sPod(event tp.K8sPodEvent, reevaluate bool) {
+	// create a pod
+
+	pod := tp.K8sPod{}
+
+	pod.Metadata = map[string]string{}
+	pod.Metadata["namespaceName"] = event.Object.ObjectMeta.Namespace
+	pod.Metadata["podName"] = event.Object.ObjectMeta.Name
+
+	// get the workload (Deployment, StatefulSet, DaemonSet, or CronJob) controlling the pod
+	if workloadKind, workloadName := K8s.GetWorkloadControllingPod(pod.Metadata["namespaceName"], event.Object.ObjectMeta.OwnerReferences); workloadName != "" {
+		pod.Metadata["workloadKind"] = workloadKind
+		pod.Metadata["workloadName"] = workloadName
+	}
+
+	pod.Annotations = map[string]string{}
+	for k, v := range event.Object.Annotations {
+		pod.Annotations[k] = v
+	}
+
+	// inherit the annotations of the namespace if the pod does not have its own ones
+	namespaceAnnotations := dm.GetNamespaceAnnotations(pod.Metadata["namespaceName"])
+	for _, key := range []string{"kubearmor-policy", "kubearmor-visibility"} {
+		if _, ok := pod.Annotations[key]; !ok {
+			if val, ok := namespaceAnnotations[key]; ok {
+				pod.Annotations[key] = val
+			}
+		}
+	}
+
+	pod.Labels = map[string]string{}
+	for k, v := range event.Object.Labels {
+		if k == "pod-template-hash" {
+			continue
+		}
+
+		if k == "pod-template-generation" {
+			continue
+		}
+
+		if k == "controller-revision-hash" {
+			continue
+		}
+		pod.Labels[k] = v
+	}
+
+	pod.Containers = map[string]string{}
+	for _, container := range event.Object.Status.ContainerStatuses {
+		if len(container.ContainerID) > 0 {
+			if strings.HasPrefix(container.ContainerID, "docker://") {
+				containerID := strings.TrimPrefix(container.ContainerID, "docker://")
+				pod.Containers[containerID] = container.Name
+			} else if strings.HasPrefix(container.ContainerID, "containerd://") {
+				containerID := strings.TrimPrefix(container.ContainerID, "containerd://")
+				pod.Containers[containerID] = container.Name
+			}
+		}
+	}
+
+	// get the seccomp profiles managed by KubeArmor
+	for _, container := range event.Object.Spec.Containers {
+		if seccompProfile := GetSeccompProfileName(event.Object, container); strings.HasPrefix(seccompProfile, "kubearmor/") {
+			pod.Metadata["seccomp-"+container.Name] = seccompProfile
+		}
+	}
+
+	if dm.EnableEnforcerPerPod {
+		if _, ok := pod.Annotations["kubearmor-policy"]; ok {
+			if pod.Annotations["kubearmor-policy"] != "enabled" && pod.Annotations["kubearmor-policy"] != "disabled" {
+				pod.Annotations["kubearmor-policy"] = "audited"
+			}
+		} else {
+			pod.Annotations["kubearmor-policy"] = "audited"
+		}
+	} else { // EnableEnforcerAll
+		if _, ok := pod.Annotations["kubearmor-policy"]; ok {
+			if pod.Annotations["kubearmor-policy"] != "enabled" && pod.Annotations["kubearmor-policy"] != "disabled" && pod.Annotations["kubearmor-policy"] != "audited" {
+				pod.Annotations["kubearmor-policy"] = "enabled"
+			}
+		} else {
+			pod.Annotations["kubearmor-policy"] = "enabled"
+		}
+	}
+
+	// == //
+
+	if pod.Metadata["namespaceName"] == "kube-system" {
+		// exception: kubernetes app
+		if _, ok := pod.Labels["k8s-app"]; ok {
+			pod.Annotations["kubearmor-policy"] = "audited"
+		}
+
+		// exception: cilium-operator
+		if val, ok := pod.Labels["io.cilium/app"]; ok && val == "operator" {
+			pod.Annotations["kubearmor-policy"] = "audited"
+		}
+	}
+
+	// == //
+
+	if dm.RuntimeEnforcer.IsEnabled() {
+		if lsm, err := ioutil.ReadFile("/sys/kernel/security/lsm"); err == nil {
+			// exception: no AppArmor
+			if !strings.Contains(string(lsm), "apparmor") {
+				if pod.Annotations["kubearmor-policy"] == "enabled" {
+					pod.Annotations["kubearmor-policy"] = "audited"
+				}
+			}
+		}
+	} else { // No LSM
+		if pod.Annotations["kubearmor-policy"] == "enabled" {
+			pod.Annotations["kubearmor-policy"] = "audited"
+		}
+	}
+
+	if _, ok := pod.Annotations["kubearmor-visibility"]; !ok {
+		pod.Annotations["kubearmor-visibility"] = "none"
+	}
+
+	if !reevaluate && (event.Type == "ADDED" || event.Type == "MODIFIED") {
+		exist := false
+
+		dm.K8sPodsLock.Lock()
+		for _, k8spod := range dm.K8sPods {
+			if k8spod.Metadata["namespaceName"] == pod.Metadata["namespaceName"] && k8spod.Metadata["podName"] == pod.Metadata["podName"] {
+				if k8spod.Annotations["kubearmor-policy"] == "patched" {
+					exist = true
+					break
 				}
+			}
+		}
+		dm.K8sPodsLock.Unlock()
+
+		if exist {
+			return
+		}
+	}
+
+	// == AppArmor == //
+
+	if pod.Annotations["kubearmor-policy"] == "enabled" {
+		appArmorAnnotations := map[string]string{}
+		updateAppArmor := false
 
-				pod.Annotations = map[string]string{}
-				for k, v := range event.Object.Annotations {
-					pod.Annotations[k] = v
+		for k, v := range pod.Annotations {
+			if strings.HasPrefix(k, "container.apparmor.security.beta.kubernetes.io") {
+				if v == "unconfined" {
+					containerName := strings.Split(k, "/")[1]
+					appArmorAnnotations[containerName] = v
+				} else {
+					containerName := strings.Split(k, "/")[1]
+					appArmorAnnotations[containerName] = strings.Split(v, "/")[1]
 				}
+			}
+		}
 
-				pod.Labels = map[string]string{}
-				for k, v := range event.Object.Labels {
-					if k == "pod-template-hash" {
-						continue
-					}
+		for _, container := range event.Object.Spec.Containers {
+			if _, ok := appArmorAnnotations[container.Name]; !ok {
+				appArmorAnnotations[container.Name] = "kubearmor-" + pod.Metadata["namespaceName"] + "-" + container.Name
+				updateAppArmor = true
+			}
+		}
 
-					if k == "pod-template-generation" {
-						continue
+		if dm.RuntimeEnforcer.GetEnforcerType() == "apparmor" {
+			if updateAppArmor && (event.Type == "ADDED" || event.Type == "MODIFIED") {
+				if workloadName, ok := pod.Metadata["workloadName"]; ok {
+					if err := K8s.PatchWorkloadWithAppArmorAnnotations(pod.Metadata["namespaceName"], pod.Metadata["workloadKind"], workloadName, appArmorAnnotations); err != nil {
+						dm.LogFeeder.Errf("Failed to update AppArmor Profiles (%s/%s/%s, %s)", pod.Metadata["namespaceName"], workloadName, pod.Metadata["podName"], err.Error())
+					} else {
+						dm.LogFeeder.Printf("Patched AppArmor Profiles (%s/%s/%s)", pod.Metadata["namespaceName"], workloadName, pod.Metadata["podName"])
 					}
 
-					if k == "controller-revision-hash" {
-						continue
+					// the running pod of a cronjob is not replaced, only the next jobs get the profiles
+					if pod.Metadata["workloadKind"] != "CronJob" {
+						pod.Annotations["kubearmor-policy"] = "patched"
 					}
-					pod.Labels[k] = v
+				} else if event.Type == "ADDED" {
+					// standalone jobs and bare pods have no pod templates to patch, so only the webhook can add the profiles at their creation
+					dm.LogFeeder.Printf("Skipped AppArmor Profiles (%s/%s, not controlled by a Deployment, StatefulSet, DaemonSet, or CronJob, unenforced unless the webhook is enabled)", pod.Metadata["namespaceName"], pod.Metadata["podName"])
 				}
+			}
+		}
+	}
+
+	// == SELinux == //
+
+	if pod.Annotations["kubearmor-policy"] == "enabled" {
+		pod.HostVolumes = []tp.HostVolumeMount{}
+		seLinuxContexts := map[string]string{}
+		updateSELinux := false
+		skipSELinux := false
+
+		for _, v := range event.Object.Spec.Volumes {
+			if v.HostPath != nil {
+				hostVolume := tp.HostVolumeMount{}
+
+				hostVolume.UsedByContainerReadOnly = map[string]bool{}
+				hostVolume.UsedByContainerPath = map[string]string{}
+
+				hostVolume.VolumeName = v.Name
+				hostVolume.PathName = v.HostPath.Path
+				hostVolume.Type = string(*v.HostPath.Type)
 
-				pod.Containers = map[string]string{}
-				for _, container := range event.Object.Status.ContainerStatuses {
-					if len(container.ContainerID) > 0 {
-						if strings.HasPrefix(container.ContainerID, "docker://") {
-							containerID := strings.TrimPrefix(container.ContainerID, "docker://")
-							pod.Containers[containerID] = container.Name
-						} else if strings.HasPrefix(container.ContainerID, "containerd://") {
-							containerID := strings.TrimPrefix(container.ContainerID, "containerd://")
-							pod.Containers[containerID] = container.Name
+				pod.HostVolumes = append(pod.HostVolumes, hostVolume)
+			}
+		}
+
+		for _, container := range event.Object.Spec.Containers {
+			// match container volumes to host mounted volume
+			for _, containerVolume := range container.VolumeMounts {
+				for i, hostVoulme := range pod.HostVolumes {
+					if containerVolume.Name == hostVoulme.VolumeName {
+						if _, ok := pod.HostVolumes[i].UsedByContainerReadOnly[container.Name]; !ok {
+							pod.HostVolumes[i].UsedByContainerReadOnly[container.Name] = containerVolume.ReadOnly
+							pod.HostVolumes[i].UsedByContainerPath[container.Name] = containerVolume.MountPath
 						}
 					}
 				}
+			}
 
-				if dm.EnableEnforcerPerPod {
-					if _, ok := pod.Annotations["kubearmor-policy"]; ok {
-						if pod.Annotations["kubearmor-policy"] != "enabled" && pod.Annotations["kubearmor-policy"] != "disabled" {
-							pod.Annotations["kubearmor-policy"] = "audited"
-						}
-					} else {
-						pod.Annotations["kubearmor-policy"] = "audited"
-					}
-				} else { // EnableEnforcerAll
-					if _, ok := pod.Annotations["kubearmor-policy"]; ok {
-						if pod.Annotations["kubearmor-policy"] != "enabled" && pod.Annotations["kubearmor-policy"] != "disabled" && pod.Annotations["kubearmor-policy"] != "audited" {
-							pod.Annotations["kubearmor-policy"] = "enabled"
-						}
-					} else {
-						pod.Annotations["kubearmor-policy"] = "enabled"
+			if container.SecurityContext != nil && container.SecurityContext.SELinuxOptions != nil {
+				if strings.Contains(container.SecurityContext.SELinuxOptions.Type, ".process") {
+					if _, ok := pod.Metadata["selinux-"+container.Name]; !ok {
+						selinuxContext := strings.Split(container.SecurityContext.SELinuxOptions.Type, ".process")[0]
+						pod.Metadata["selinux-"+container.Name] = selinuxContext
 					}
 				}
+			}
+		}
 
-				// == //
-
-				if pod.Metadata["namespaceName"] == "kube-system" {
-					// exception: kubernetes app
-					if _, ok := pod.Labels["k8s-app"]; ok {
-						pod.Annotations["kubearmor-policy"] = "audited"
+		for _, container := range event.Object.Spec.Containers {
+			if container.SecurityContext == nil || container.SecurityContext.SELinuxOptions == nil || container.SecurityContext.SELinuxOptions.Type == "" {
+				if _, ok1 := seLinuxContexts[container.Name]; !ok1 {
+					if _, ok2 := pod.Metadata["workloadName"]; !ok2 {
+						skipSELinux = true
+						continue
 					}
 
-					// exception: cilium-operator
-					if val, ok := pod.Labels["io.cilium/app"]; ok && val == "operator" {
-						pod.Annotations["kubearmor-policy"] = "audited"
+					container.SecurityContext = &v1.SecurityContext{
+						SELinuxOptions: &v1.SELinuxOptions{
+							Type: "kubearmor-" + pod.Metadata["namespaceName"] + "-" + pod.Metadata["workloadName"] + "-" + container.Name + ".process",
+						},
 					}
-				}
 
-				// == //
+					// clear container volume, if not delete volumeMounts, rolling update error
+					container.VolumeMounts = []v1.VolumeMount{}
 
-				if dm.RuntimeEnforcer.IsEnabled() {
-					if lsm, err := ioutil.ReadFile("/sys/kernel/security/lsm"); err == nil {
-						// exception: no AppArmor
-						if !strings.Contains(string(lsm), "apparmor") {
-							if pod.Annotations["kubearmor-policy"] == "enabled" {
-								pod.Annotations["kubearmor-policy"] = "audited"
-							}
-						}
+					b, _ := json.Marshal(container)
+					seLinuxContexts[container.Name] = string(b)
+
+					// set update flag
+					updateSELinux = true
+				}
+			}
+		}
+
+		// if no selinux annotations but kubearmor-policy is enabled, add selinux annotations
+		if dm.RuntimeEnforcer.GetEnforcerType() == "selinux" {
+			if updateSELinux && (event.Type == "ADDED" || event.Type == "MODIFIED") {
+				if workloadName, ok := pod.Metadata["workloadName"]; ok {
+					if err := K8s.PatchWorkloadWithSELinuxOptions(pod.Metadata["namespaceName"], pod.Metadata["workloadKind"], workloadName, seLinuxContexts); err != nil {
+						dm.LogFeeder.Errf("Failed to update SELinux security options (%s/%s/%s, %s)", pod.Metadata["namespaceName"], workloadName, pod.Metadata["podName"], err.Error())
+					} else {
+						dm.LogFeeder.Printf("Patched SELinux security options (%s/%s/%s)", pod.Metadata["namespaceName"], workloadName, pod.Metadata["podName"])
 					}
-				} else { // No LSM
-					if pod.Annotations["kubearmor-policy"] == "enabled" {
-						pod.Annotations["kubearmor-policy"] = "audited"
+
+					// the running pod of a cronjob is not replaced, only the next jobs get the security options
+					if pod.Metadata["workloadKind"] != "CronJob" {
+						pod.Annotations["kubearmor-policy"] = "patched"
 					}
 				}
+			} else if skipSELinux && event.Type == "ADDED" {
+				// standalone jobs and bare pods have no pod templates to patch, so only the webhook can add the options at their creation
+				dm.LogFeeder.Printf("Skipped SELinux security options (%s/%s, not controlled by a Deployment, StatefulSet, DaemonSet, or CronJob, unenforced unless the webhook is enabled)", pod.Metadata["namespaceName"], pod.Metadata["podName"])
+			}
+		}
+	}
 
-				if _, ok := pod.Annotations["kubearmor-visibility"]; !ok {
-					pod.Annotations["kubearmor-visibility"] = "none"
-				}
+	// == //
 
-				if event.Type == "ADDED" || event.Type == "MODIFIED" {
-					exist := false
+	// update the pod into the pod list
 
-					dm.K8sPodsLock.Lock()
-					for _, k8spod := range dm.K8sPods {
-						if k8spod.Metadata["namespaceName"] == pod.Metadata["namespaceName"] && k8spod.Metadata["podName"] == pod.Metadata["podName"] {
-							if k8spod.Annotations["kubearmor-policy"] == "patched" {
-								exist = true
-								break
-							}
-						}
-					}
-					dm.K8sPodsLock.Unlock()
+	dm.K8sPodsLock.Lock()
 
-					if exist {
-						continue
-					}
+	if event.Type == "ADDED" {
+		if !kl.ContainsElement(dm.K8sPods, pod) {
+			dm.K8sPods = append(dm.K8sPods, pod)
+		}
+	} else if event.Type == "MODIFIED" {
+		for idx, k8spod := range dm.K8sPods {
+			if k8spod.Metadata["namespaceName"] == pod.Metadata["namespaceName"] && k8spod.Metadata["podName"] == pod.Metadata["podName"] {
+				dm.K8sPods[idx] = pod
+				break
+			}
+		}
+	} else if event.Type == "DELETED" {
+		for idx, k8spod := range dm.K8sPods {
+			if k8spod.Metadata["namespaceName"] == pod.Metadata["namespaceName"] && k8spod.Metadata["podName"] == pod.Metadata["podName"] {
+				dm.K8sPods = append(dm.K8sPods[:idx], dm.K8sPods[idx+1:]...)
+				break
+			}
+		}
+	} else { // Otherwise
+		dm.K8sPodsLock.Unlock()
+		return
+	}
+
+	dm.K8sPodsLock.Unlock()
+
+	if pod.Annotations["kubearmor-policy"] != "patched" {
+		dm.LogFeeder.Printf("Detected a Pod (%s/%s/%s)", strings.ToLower(event.Type), pod.Metadata["namespaceName"], pod.Metadata["podName"])
+	}
+
+	// update a endpoint corresponding to the pod
+	dm.UpdateEndPointWithPod(event.Type, pod)
+}
+
+// WatchK8sPods Function
+func (dm *KubeArmorDaemon) WatchK8sPods() {
+	for {
+		if resp := K8s.WatchK8sPods(); resp != nil {
+			defer resp.Body.Close()
+
+			decoder := json.NewDecoder(resp.Body)
+			for {
+				event := tp.K8sPodEvent{}
+				if err := decoder.Decode(&event); err == io.EOF {
+					break
+				} else if err != nil {
+					break
 				}
 
-				// == AppArmor == //
+				dm.UpdateK8sPod(event)
+			}
+		} else {
+			time.Sleep(time.Second * 1)
+		}
+	}
+}
 
-				if pod.Annotations["kubearmor-policy"] == "enabled" {
-					appArmorAnnotations := map[string]string{}
-					updateAppArmor := false
+// ==================== //
+// == Pod Quarantine == //
+// ==================== //
 
-					for k, v := range pod.Annotations {
-						if strings.HasPrefix(k, "container.apparmor.security.beta.kubernetes.io") {
-							if v == "unconfined" {
-								containerName := strings.Split(k, "/")[1]
-								appArmorAnnotations[containerName] = v
-							} else {
-								containerName := strings.Split(k, "/")[1]
-								appArmorAnnotations[containerName] = strings.Split(v, "/")[1]
-							}
-						}
-					}
+// QuarantinePod Function
+func (dm *KubeArmorDaemon) QuarantinePod(namespaceName, podName, policyName string, actions []string) {
+	key := namespaceName + "_" + podName
 
-					for _, container := range event.Object.Spec.Containers {
-						if _, ok := appArmorAnnotations[container.Name]; !ok {
-							appArmorAnnotations[container.Name] = "kubearmor-" + pod.Metadata["namespaceName"] + "-" + container.Name
-							updateAppArmor = true
-						}
-					}
+	for _, action := range []string{"Label", "Isolate", "Evict"} {
+		if !kl.ContainsElement(actions, action) {
+			continue
+		}
 
-					if dm.RuntimeEnforcer.GetEnforcerType() == "apparmor" {
-						if updateAppArmor && (event.Type == "ADDED" || event.Type == "MODIFIED") {
-							if deploymentName, ok := pod.Metadata["deploymentName"]; ok {
-								if err := K8s.PatchDeploymentWithAppArmorAnnotations(pod.Metadata["namespaceName"], deploymentName, appArmorAnnotations); err != nil {
-									dm.LogFeeder.Errf("Failed to update AppArmor Profiles (%s/%s/%s, %s)", pod.Metadata["namespaceName"], deploymentName, pod.Metadata["podName"], err.Error())
-								} else {
-									dm.LogFeeder.Printf("Patched AppArmor Profiles (%s/%s/%s)", pod.Metadata["namespaceName"], deploymentName, pod.Metadata["podName"])
-								}
-								pod.Annotations["kubearmor-policy"] = "patched"
-							}
-						}
-					}
+		// take each action only once for a pod
+		dm.QuarantinedPodsLock.Lock()
+		if kl.ContainsElement(dm.QuarantinedPods[key], action) {
+			dm.QuarantinedPodsLock.Unlock()
+			continue
+		}
+		dm.QuarantinedPods[key] = append(dm.QuarantinedPods[key], action)
+		dm.QuarantinedPodsLock.Unlock()
+
+		var err error
+
+		switch action {
+		case "Label":
+			err = K8s.LabelPodForQuarantine(namespaceName, podName)
+		case "Isolate":
+			err = K8s.IsolatePod(namespaceName, podName)
+		case "Evict":
+			err = K8s.EvictPod(namespaceName, podName)
+		}
+
+		if err != nil {
+			// allow to retry the action with the next alert
+			dm.QuarantinedPodsLock.Lock()
+			for idx, taken := range dm.QuarantinedPods[key] {
+				if taken == action {
+					dm.QuarantinedPods[key] = append(dm.QuarantinedPods[key][:idx], dm.QuarantinedPods[key][idx+1:]...)
+					break
 				}
+			}
+			dm.QuarantinedPodsLock.Unlock()
 
-				// == SELinux == //
+			dm.LogFeeder.Errf("Failed to quarantine a pod (%s, %s/%s, %s, %s)", strings.ToLower(action), namespaceName, podName, policyName, err.Error())
+		} else {
+			dm.LogFeeder.Printf("Quarantined a pod (%s, %s/%s, %s)", strings.ToLower(action), namespaceName, podName, policyName)
+		}
+	}
+}
 
-				if pod.Annotations["kubearmor-policy"] == "enabled" {
-					pod.HostVolumes = []tp.HostVolumeMount{}
-					seLinuxContexts := map[string]string{}
-					updateSELinux := false
+// QuarantinePods Function
+func (dm *KubeArmorDaemon) QuarantinePods() {
+	for log := range fd.QuarantineQueue {
+		dm.QuarantinePod(log.NamespaceName, log.PodName, log.PolicyName, log.QuarantineActions)
+	}
+}
 
-					for _, v := range event.Object.Spec.Volumes {
-						if v.HostPath != nil {
-							hostVolume := tp.HostVolumeMount{}
+// ================================ //
+// == Policy Violation Reporting == //
+// ================================ //
 
-							hostVolume.UsedByContainerReadOnly = map[string]bool{}
-							hostVolume.UsedByContainerPath = map[string]string{}
+// ViolationEventInterval for aggregating the alerts of a pod and a policy into an event
+const ViolationEventInterval = time.Second * 30
 
-							hostVolume.VolumeName = v.Name
-							hostVolume.PathName = v.HostPath.Path
-							hostVolume.Type = string(*v.HostPath.Type)
+// ViolationEventLimit for the number of events written in each interval
+const ViolationEventLimit = 100
 
-							pod.HostVolumes = append(pod.HostVolumes, hostVolume)
-						}
-					}
+// ViolationEventExpiry for forgetting the events not updated (the TTL of events in kube-apiserver)
+const ViolationEventExpiry = time.Hour
 
-					for _, container := range event.Object.Spec.Containers {
-						// match container volumes to host mounted volume
-						for _, containerVolume := range container.VolumeMounts {
-							for i, hostVoulme := range pod.HostVolumes {
-								if containerVolume.Name == hostVoulme.VolumeName {
-									if _, ok := pod.HostVolumes[i].UsedByContainerReadOnly[container.Name]; !ok {
-										pod.HostVolumes[i].UsedByContainerReadOnly[container.Name] = containerVolume.ReadOnly
-										pod.HostVolumes[i].UsedByContainerPath[container.Name] = containerVolume.MountPath
-									}
-								}
-							}
-						}
+// GetViolationMessage Function
+func GetViolationMessage(log tp.Log) string {
+	resource := log.Resource
+	if len(resource) > 128 {
+		resource = resource[:125] + "..."
+	}
 
-						if container.SecurityContext != nil && container.SecurityContext.SELinuxOptions != nil {
-							if strings.Contains(container.SecurityContext.SELinuxOptions.Type, ".process") {
-								if _, ok := pod.Metadata["selinux-"+container.Name]; !ok {
-									selinuxContext := strings.Split(container.SecurityContext.SELinuxOptions.Type, ".process")[0]
-									pod.Metadata["selinux-"+container.Name] = selinuxContext
-								}
-							}
-						}
-					}
+	return fmt.Sprintf("%s: %s %s (%s)", log.PolicyName, log.Operation, resource, log.Action)
+}
 
-					for _, container := range event.Object.Spec.Containers {
-						if container.SecurityContext == nil || container.SecurityContext.SELinuxOptions == nil || container.SecurityContext.SELinuxOptions.Type == "" {
-							if _, ok1 := seLinuxContexts[container.Name]; !ok1 {
-								if _, ok2 := pod.Metadata["deploymentName"]; !ok2 {
-									continue
-								}
+// UpdateViolationEvent Function
+func (dm *KubeArmorDaemon) UpdateViolationEvent(violations map[string]*tp.K8sViolationEvent, log tp.Log) {
+	key := log.NamespaceName + "_" + log.PodName + "_" + log.PolicyName
+
+	violation, ok := violations[key]
+	if !ok {
+		violation = &tp.K8sViolationEvent{
+			NamespaceName: log.NamespaceName,
+			PodName:       log.PodName,
+			PolicyName:    log.PolicyName,
+			FirstTime:     time.Now(),
+		}
+		violations[key] = violation
+	}
 
-								container.SecurityContext = &v1.SecurityContext{
-									SELinuxOptions: &v1.SELinuxOptions{
-										Type: "kubearmor-" + pod.Metadata["namespaceName"] + "-" + pod.Metadata["deploymentName"] + "-" + container.Name + ".process",
-									},
-								}
+	// audited alerts are normal, but blocked (or killed) ones are warnings
+	if strings.HasPrefix(log.Action, "Audit") {
+		if violation.Pending == 0 {
+			violation.Type = "Normal"
+		}
+	} else {
+		violation.Type = "Warning"
+	}
 
-								// clear container volume, if not delete volumeMounts, rolling update error
-								container.VolumeMounts = []v1.VolumeMount{}
+	violation.Message = GetViolationMessage(log)
+	violation.Pending++
+	violation.LastTime = time.Now()
+}
 
-								b, _ := json.Marshal(container)
-								seLinuxContexts[container.Name] = string(b)
+// WriteViolationEvents Function
+func (dm *KubeArmorDaemon) WriteViolationEvents(violations map[string]*tp.K8sViolationEvent) {
+	written := 0
 
-								// set update flag
-								updateSELinux = true
-							}
-						}
-					}
+	for key, violation := range violations {
+		if violation.Pending == 0 {
+			if time.Since(violation.LastTime) > ViolationEventExpiry {
+				delete(violations, key)
+			}
+			continue
+		}
 
-					// if no selinux annotations but kubearmor-policy is enabled, add selinux annotations
-					if dm.RuntimeEnforcer.GetEnforcerType() == "selinux" {
-						if updateSELinux && (event.Type == "ADDED" || event.Type == "MODIFIED") {
-							if deploymentName, ok := pod.Metadata["deploymentName"]; ok {
-								if err := K8s.PatchDeploymentWithSELinuxOptions(pod.Metadata["namespaceName"], deploymentName, seLinuxContexts); err != nil {
-									dm.LogFeeder.Errf("Failed to update SELinux security options (%s/%s/%s, %s)", pod.Metadata["namespaceName"], deploymentName, pod.Metadata["podName"], err.Error())
-								} else {
-									dm.LogFeeder.Printf("Patched SELinux security options (%s/%s/%s)", pod.Metadata["namespaceName"], deploymentName, pod.Metadata["podName"])
-								}
-								pod.Annotations["kubearmor-policy"] = "patched"
-							}
-						}
-					}
+		// the rest is written in the next interval
+		if written >= ViolationEventLimit {
+			break
+		}
+		written++
+
+		violation.Count += violation.Pending
+
+		if violation.EventName != "" {
+			if err := K8s.UpdateViolationEvent(*violation); err == nil {
+				violation.Pending = 0
+				continue
+			}
+
+			// the event might be expired, create a new one
+			violation.EventName = ""
+		}
+
+		if eventName, err := K8s.CreateViolationEvent(*violation); err == nil {
+			violation.EventName = eventName
+			violation.Pending = 0
+		} else {
+			violation.Count -= violation.Pending
+
+			// the pod is gone
+			if time.Since(violation.LastTime) > ViolationEventInterval {
+				delete(violations, key)
+			}
+		}
+	}
+}
+
+// ReportViolationEvents Function
+func (dm *KubeArmorDaemon) ReportViolationEvents() {
+	violations := map[string]*tp.K8sViolationEvent{}
+
+	ticker := time.NewTicker(ViolationEventInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-StopChan:
+			return
+		case log := <-fd.EventQueue:
+			dm.UpdateViolationEvent(violations, log)
+		case <-ticker.C:
+			dm.WriteViolationEvents(violations)
+		}
+	}
+}
+
+// ====================== //
+// == Namespace Update == //
+// ====================== //
+
+// GetNamespaceAnnotations Function
+func (dm *KubeArmorDaemon) GetNamespaceAnnotations(namespaceName string) map[string]string {
+	dm.K8sNamespacesLock.RLock()
+	defer dm.K8sNamespacesLock.RUnlock()
+
+	annotations := map[string]string{}
+
+	if namespace, ok := dm.K8sNamespaces[namespaceName]; ok {
+		for k, v := range namespace.Annotations {
+			annotations[k] = v
+		}
+	}
+
+	return annotations
+}
+
+// GetNamespaceLabels Function
+func (dm *KubeArmorDaemon) GetNamespaceLabels(namespaceName string) []string {
+	dm.K8sNamespacesLock.RLock()
+	namespace, ok := dm.K8sNamespaces[namespaceName]
+	dm.K8sNamespacesLock.RUnlock()
+
+	// the namespaces not watched yet are read from k8s api client
+	if !ok {
+		return K8s.GetNamespaceLabels(namespaceName)
+	}
+
+	namespaceLabels := []string{}
+
+	for k, v := range namespace.Labels {
+		namespaceLabels = append(namespaceLabels, k+"="+v)
+	}
+
+	return namespaceLabels
+}
+
+// WatchK8sNamespaces Function
+func (dm *KubeArmorDaemon) WatchK8sNamespaces() {
+	for {
+		if resp := K8s.WatchK8sNamespaces(); resp != nil {
+			defer resp.Body.Close()
+
+			decoder := json.NewDecoder(resp.Body)
+			for {
+				event := tp.K8sNamespaceEvent{}
+				if err := decoder.Decode(&event); err == io.EOF {
+					break
+				} else if err != nil {
+					break
 				}
 
-				// == //
+				if event.Type != "ADDED" && event.Type != "MODIFIED" && event.Type != "DELETED" {
+					continue
+				}
 
-				// update the pod into the pod list
+				// create a namespace
 
-				dm.K8sPodsLock.Lock()
+				namespace := tp.K8sNamespace{}
 
-				if event.Type == "ADDED" {
-					if !kl.ContainsElement(dm.K8sPods, pod) {
-						dm.K8sPods = append(dm.K8sPods, pod)
-					}
-				} else if event.Type == "MODIFIED" {
-					for idx, k8spod := range dm.K8sPods {
-						if k8spod.Metadata["namespaceName"] == pod.Metadata["namespaceName"] && k8spod.Metadata["podName"] == pod.Metadata["podName"] {
-							dm.K8sPods[idx] = pod
-							break
-						}
-					}
-				} else if event.Type == "DELETED" {
-					for idx, k8spod := range dm.K8sPods {
-						if k8spod.Metadata["namespaceName"] == pod.Metadata["namespaceName"] && k8spod.Metadata["podName"] == pod.Metadata["podName"] {
-							dm.K8sPods = append(dm.K8sPods[:idx], dm.K8sPods[idx+1:]...)
-							break
-						}
+				namespace.Name = event.Object.ObjectMeta.Name
+
+				namespace.Annotations = map[string]string{}
+				for _, key := range []string{"kubearmor-policy", "kubearmor-visibility"} {
+					if val, ok := event.Object.Annotations[key]; ok {
+						namespace.Annotations[key] = val
 					}
-				} else { // Otherwise
-					dm.K8sPodsLock.Unlock()
+				}
+
+				namespace.Labels = map[string]string{}
+				for k, v := range event.Object.Labels {
+					namespace.Labels[k] = v
+				}
+
+				// update the namespace into the namespace map
+
+				dm.K8sNamespacesLock.Lock()
+
+				prevNamespace, exist := dm.K8sNamespaces[namespace.Name]
+
+				if event.Type == "DELETED" {
+					delete(dm.K8sNamespaces, namespace.Name)
+				} else {
+					dm.K8sNamespaces[namespace.Name] = namespace
+				}
+
+				dm.K8sNamespacesLock.Unlock()
+
+				if event.Type == "DELETED" {
 					continue
 				}
 
-				dm.K8sPodsLock.Unlock()
+				if !exist && len(namespace.Annotations) == 0 {
+					continue
+				}
 
-				if pod.Annotations["kubearmor-policy"] != "patched" {
-					dm.LogFeeder.Printf("Detected a Pod (%s/%s/%s)", strings.ToLower(event.Type), pod.Metadata["namespaceName"], pod.Metadata["podName"])
+				if exist && reflect.DeepEqual(prevNamespace.Annotations, namespace.Annotations) && reflect.DeepEqual(prevNamespace.Labels, namespace.Labels) {
+					continue
 				}
 
-				// update a endpoint corresponding to the pod
-				dm.UpdateEndPointWithPod(event.Type, pod)
+				dm.LogFeeder.Printf("Detected a Namespace (%s/%s)", strings.ToLower(event.Type), namespace.Name)
+
+				// re-evaluate the pods in the namespace with the updated annotations and labels
+				for _, k8sPod := range K8s.GetK8sPods(namespace.Name) {
+					dm.ReevaluateK8sPod(tp.K8sPodEvent{Type: "MODIFIED", Object: k8sPod})
+				}
 			}
 		} else {
 			time.Sleep(time.Second * 1)
@@ -561,15 +975,101 @@ func (dm *KubeArmorDaemon) WatchK8sPods() {
 // == Security Policy Update == //
 // ============================ //
 
+// MatchExpressions Function
+func MatchExpressions(expressions []tp.MatchExpressionType, labels []string) bool {
+	for _, expression := range expressions {
+		value, exists := "", false
+
+		for _, label := range labels {
+			if kv := strings.SplitN(label, "=", 2); len(kv) == 2 && kv[0] == expression.Key {
+				value, exists = kv[1], true
+				break
+			}
+		}
+
+		switch expression.Operator {
+		case "In":
+			if !exists || !kl.ContainsElement(expression.Values, value) {
+				return false
+			}
+		case "NotIn":
+			if exists && kl.ContainsElement(expression.Values, value) {
+				return false
+			}
+		case "Exists":
+			if !exists {
+				return false
+			}
+		case "DoesNotExist":
+			if exists {
+				return false
+			}
+		default: // unknown operator
+			return false
+		}
+	}
+
+	return true
+}
+
+// isKubeArmorEndPoint Function
+func isKubeArmorEndPoint(endPoint tp.EndPoint) bool {
+	for _, identity := range endPoint.Identities {
+		if strings.HasPrefix(identity, "kubearmor-app=") {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchSecurityPolicy Function
+func MatchSecurityPolicy(secPolicy tp.SecurityPolicy, endPoint tp.EndPoint, untrackedNamespaces []string) bool {
+	// match label expressions
+	if !MatchExpressions(secPolicy.Spec.Selector.MatchExpressions, endPoint.Labels) {
+		return false
+	}
+
+	// namespaced policy
+	if secPolicy.Metadata["namespaceName"] != "" {
+		return kl.MatchIdentities(secPolicy.Spec.Selector.Identities, endPoint.Identities)
+	}
+
+	// cluster policy, match namespace names
+	if len(secPolicy.Spec.NamespaceSelector.MatchNames) > 0 {
+		if !kl.ContainsElement(secPolicy.Spec.NamespaceSelector.MatchNames, endPoint.NamespaceName) {
+			return false
+		}
+	} else if endPoint.NamespaceName == "kube-system" || kl.ContainsElement(untrackedNamespaces, endPoint.NamespaceName) || isKubeArmorEndPoint(endPoint) {
+		// the system namespaces and KubeArmor itself are only selected by their names
+		return false
+	}
+
+	// match namespace labels
+	for _, identity := range secPolicy.Spec.NamespaceSelector.Identities {
+		if !kl.ContainsElement(endPoint.NamespaceLabels, identity) {
+			return false
+		}
+	}
+
+	// match pod labels (no labels selects all pods in the matched namespaces)
+	for _, identity := range secPolicy.Spec.Selector.Identities {
+		if !kl.ContainsElement(endPoint.Identities, identity) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // GetSecurityPolicies Function
-func (dm *KubeArmorDaemon) GetSecurityPolicies(identities []string) []tp.SecurityPolicy {
+func (dm *KubeArmorDaemon) GetSecurityPolicies(endPoint tp.EndPoint) []tp.SecurityPolicy {
 	dm.SecurityPoliciesLock.Lock()
 	defer dm.SecurityPoliciesLock.Unlock()
 
 	secPolicies := []tp.SecurityPolicy{}
 
 	for _, policy := range dm.SecurityPolicies {
-		if kl.MatchIdentities(policy.Spec.Selector.Identities, identities) {
+		if MatchSecurityPolicy(policy, endPoint, dm.UntrackedNamespaces) {
 			secPolicy := tp.SecurityPolicy{}
 			if err := kl.Clone(policy, &secPolicy); err != nil {
 				fmt.Println("Failed to clone a policy")
@@ -588,7 +1088,7 @@ func (dm *KubeArmorDaemon) UpdateSecurityPolicy(action string, secPolicy tp.Secu
 
 	for idx, endPoint := range dm.EndPoints {
 		// update a security policy
-		if kl.MatchIdentities(secPolicy.Spec.Selector.Identities, endPoint.Identities) {
+		if MatchSecurityPolicy(secPolicy, endPoint, dm.UntrackedNamespaces) {
 			if action == "ADDED" {
 				// add a new security policy if it doesn't exist
 				if !kl.ContainsElement(endPoint.SecurityPolicies, secPolicy) {
@@ -622,13 +1122,23 @@ func (dm *KubeArmorDaemon) UpdateSecurityPolicy(action string, secPolicy tp.Secu
 
 // WatchSecurityPolicies Function
 func (dm *KubeArmorDaemon) WatchSecurityPolicies() {
+	dm.WatchSecurityPoliciesWithCRD("kubearmorpolicies", K8s.WatchK8sSecurityPolicies)
+}
+
+// WatchClusterSecurityPolicies Function
+func (dm *KubeArmorDaemon) WatchClusterSecurityPolicies() {
+	dm.WatchSecurityPoliciesWithCRD("kubearmorclusterpolicies", K8s.WatchK8sClusterSecurityPolicies)
+}
+
+// WatchSecurityPoliciesWithCRD Function
+func (dm *KubeArmorDaemon) WatchSecurityPoliciesWithCRD(resourceName string, watchPolicies func() *http.Response) {
 	for {
-		if !K8s.CheckCustomResourceDefinition("kubearmorpolicies") {
+		if !K8s.CheckCustomResourceDefinition(resourceName) {
 			time.Sleep(time.Second * 1)
 			continue
 		}
 
-		if resp := K8s.WatchK8sSecurityPolicies(); resp != nil {
+		if resp := watchPolicies(); resp != nil {
 			defer resp.Body.Close()
 
 			decoder := json.NewDecoder(resp.Body)
@@ -665,6 +1175,10 @@ func (dm *KubeArmorDaemon) WatchSecurityPolicies() {
 					secPolicy.Spec.Severity = 1 // the lowest severity, by default
 				}
 
+				if len(secPolicy.Spec.Response.Actions) > 0 && secPolicy.Spec.Response.MinSeverity == 0 {
+					secPolicy.Spec.Response.MinSeverity = 10 // only the highest severity quarantines pods, by default
+				}
+
 				switch secPolicy.Spec.Action {
 				case "allow":
 					secPolicy.Spec.Action = "Allow"
@@ -672,13 +1186,19 @@ func (dm *KubeArmorDaemon) WatchSecurityPolicies() {
 					secPolicy.Spec.Action = "Audit"
 				case "block":
 					secPolicy.Spec.Action = "Block"
+				case "kill":
+					secPolicy.Spec.Action = "Kill"
+				case "stop":
+					secPolicy.Spec.Action = "Stop"
 				case "":
 					secPolicy.Spec.Action = "Block" // by default
 				}
 
 				// add identities
 
-				secPolicy.Spec.Selector.Identities = append(secPolicy.Spec.Selector.Identities, "namespaceName="+event.Object.Metadata.Namespace)
+				if event.Object.Metadata.Namespace != "" {
+					secPolicy.Spec.Selector.Identities = append(secPolicy.Spec.Selector.Identities, "namespaceName="+event.Object.Metadata.Namespace)
+				}
 
 				for k, v := range secPolicy.Spec.Selector.MatchLabels {
 					if !kl.ContainsElement(secPolicy.Spec.Selector.Identities, k+"="+v) {
@@ -686,6 +1206,14 @@ func (dm *KubeArmorDaemon) WatchSecurityPolicies() {
 					}
 				}
 
+				// add namespace identities (cluster policies)
+
+				for k, v := range secPolicy.Spec.NamespaceSelector.MatchLabels {
+					if !kl.ContainsElement(secPolicy.Spec.NamespaceSelector.Identities, k+"="+v) {
+						secPolicy.Spec.NamespaceSelector.Identities = append(secPolicy.Spec.NamespaceSelector.Identities, k+"="+v)
+					}
+				}
+
 				// add severities, tags, messages, and actions
 
 				if len(secPolicy.Spec.Process.MatchPaths) > 0 {
@@ -796,6 +1324,42 @@ func (dm *KubeArmorDaemon) WatchSecurityPolicies() {
 					}
 				}
 
+				if len(secPolicy.Spec.Process.MatchFileless) > 0 {
+					for idx, fl := range secPolicy.Spec.Process.MatchFileless {
+						if fl.Severity == 0 {
+							if secPolicy.Spec.Process.Severity != 0 {
+								secPolicy.Spec.Process.MatchFileless[idx].Severity = secPolicy.Spec.Process.Severity
+							} else {
+								secPolicy.Spec.Process.MatchFileless[idx].Severity = secPolicy.Spec.Severity
+							}
+						}
+
+						if len(fl.Tags) == 0 {
+							if len(secPolicy.Spec.Process.Tags) > 0 {
+								secPolicy.Spec.Process.MatchFileless[idx].Tags = secPolicy.Spec.Process.Tags
+							} else {
+								secPolicy.Spec.Process.MatchFileless[idx].Tags = secPolicy.Spec.Tags
+							}
+						}
+
+						if len(fl.Message) == 0 {
+							if len(secPolicy.Spec.Process.Message) > 0 {
+								secPolicy.Spec.Process.MatchFileless[idx].Message = secPolicy.Spec.Process.Message
+							} else {
+								secPolicy.Spec.Process.MatchFileless[idx].Message = secPolicy.Spec.Message
+							}
+						}
+
+						if len(fl.Action) == 0 {
+							if len(secPolicy.Spec.Process.Action) > 0 {
+								secPolicy.Spec.Process.MatchFileless[idx].Action = secPolicy.Spec.Process.Action
+							} else {
+								secPolicy.Spec.Process.MatchFileless[idx].Action = secPolicy.Spec.Action
+							}
+						}
+					}
+				}
+
 				if len(secPolicy.Spec.File.MatchPaths) > 0 {
 					for idx, path := range secPolicy.Spec.File.MatchPaths {
 						if path.Severity == 0 {
@@ -940,6 +1504,114 @@ func (dm *KubeArmorDaemon) WatchSecurityPolicies() {
 					}
 				}
 
+				if len(secPolicy.Spec.Network.MatchIPs) > 0 {
+					for idx, ip := range secPolicy.Spec.Network.MatchIPs {
+						if ip.Severity == 0 {
+							if secPolicy.Spec.Network.Severity != 0 {
+								secPolicy.Spec.Network.MatchIPs[idx].Severity = secPolicy.Spec.Network.Severity
+							} else {
+								secPolicy.Spec.Network.MatchIPs[idx].Severity = secPolicy.Spec.Severity
+							}
+						}
+
+						if len(ip.Tags) == 0 {
+							if len(secPolicy.Spec.Network.Tags) > 0 {
+								secPolicy.Spec.Network.MatchIPs[idx].Tags = secPolicy.Spec.Network.Tags
+							} else {
+								secPolicy.Spec.Network.MatchIPs[idx].Tags = secPolicy.Spec.Tags
+							}
+						}
+
+						if len(ip.Message) == 0 {
+							if len(secPolicy.Spec.Network.Message) > 0 {
+								secPolicy.Spec.Network.MatchIPs[idx].Message = secPolicy.Spec.Network.Message
+							} else {
+								secPolicy.Spec.Network.MatchIPs[idx].Message = secPolicy.Spec.Message
+							}
+						}
+
+						if len(ip.Action) == 0 {
+							if len(secPolicy.Spec.Network.Action) > 0 {
+								secPolicy.Spec.Network.MatchIPs[idx].Action = secPolicy.Spec.Network.Action
+							} else {
+								secPolicy.Spec.Network.MatchIPs[idx].Action = secPolicy.Spec.Action
+							}
+						}
+					}
+				}
+
+				if len(secPolicy.Spec.Network.MatchPorts) > 0 {
+					for idx, port := range secPolicy.Spec.Network.MatchPorts {
+						if port.Severity == 0 {
+							if secPolicy.Spec.Network.Severity != 0 {
+								secPolicy.Spec.Network.MatchPorts[idx].Severity = secPolicy.Spec.Network.Severity
+							} else {
+								secPolicy.Spec.Network.MatchPorts[idx].Severity = secPolicy.Spec.Severity
+							}
+						}
+
+						if len(port.Tags) == 0 {
+							if len(secPolicy.Spec.Network.Tags) > 0 {
+								secPolicy.Spec.Network.MatchPorts[idx].Tags = secPolicy.Spec.Network.Tags
+							} else {
+								secPolicy.Spec.Network.MatchPorts[idx].Tags = secPolicy.Spec.Tags
+							}
+						}
+
+						if len(port.Message) == 0 {
+							if len(secPolicy.Spec.Network.Message) > 0 {
+								secPolicy.Spec.Network.MatchPorts[idx].Message = secPolicy.Spec.Network.Message
+							} else {
+								secPolicy.Spec.Network.MatchPorts[idx].Message = secPolicy.Spec.Message
+							}
+						}
+
+						if len(port.Action) == 0 {
+							if len(secPolicy.Spec.Network.Action) > 0 {
+								secPolicy.Spec.Network.MatchPorts[idx].Action = secPolicy.Spec.Network.Action
+							} else {
+								secPolicy.Spec.Network.MatchPorts[idx].Action = secPolicy.Spec.Action
+							}
+						}
+					}
+				}
+
+				if len(secPolicy.Spec.Network.MatchDomains) > 0 {
+					for idx, domain := range secPolicy.Spec.Network.MatchDomains {
+						if domain.Severity == 0 {
+							if secPolicy.Spec.Network.Severity != 0 {
+								secPolicy.Spec.Network.MatchDomains[idx].Severity = secPolicy.Spec.Network.Severity
+							} else {
+								secPolicy.Spec.Network.MatchDomains[idx].Severity = secPolicy.Spec.Severity
+							}
+						}
+
+						if len(domain.Tags) == 0 {
+							if len(secPolicy.Spec.Network.Tags) > 0 {
+								secPolicy.Spec.Network.MatchDomains[idx].Tags = secPolicy.Spec.Network.Tags
+							} else {
+								secPolicy.Spec.Network.MatchDomains[idx].Tags = secPolicy.Spec.Tags
+							}
+						}
+
+						if len(domain.Message) == 0 {
+							if len(secPolicy.Spec.Network.Message) > 0 {
+								secPolicy.Spec.Network.MatchDomains[idx].Message = secPolicy.Spec.Network.Message
+							} else {
+								secPolicy.Spec.Network.MatchDomains[idx].Message = secPolicy.Spec.Message
+							}
+						}
+
+						if len(domain.Action) == 0 {
+							if len(secPolicy.Spec.Network.Action) > 0 {
+								secPolicy.Spec.Network.MatchDomains[idx].Action = secPolicy.Spec.Network.Action
+							} else {
+								secPolicy.Spec.Network.MatchDomains[idx].Action = secPolicy.Spec.Action
+							}
+						}
+					}
+				}
+
 				if len(secPolicy.Spec.Capabilities.MatchCapabilities) > 0 {
 					for idx, cap := range secPolicy.Spec.Capabilities.MatchCapabilities {
 						if cap.Severity == 0 {
@@ -976,6 +1648,78 @@ func (dm *KubeArmorDaemon) WatchSecurityPolicies() {
 					}
 				}
 
+				if len(secPolicy.Spec.Privilege.MatchSyscalls) > 0 {
+					for idx, sc := range secPolicy.Spec.Privilege.MatchSyscalls {
+						if sc.Severity == 0 {
+							if secPolicy.Spec.Privilege.Severity != 0 {
+								secPolicy.Spec.Privilege.MatchSyscalls[idx].Severity = secPolicy.Spec.Privilege.Severity
+							} else {
+								secPolicy.Spec.Privilege.MatchSyscalls[idx].Severity = secPolicy.Spec.Severity
+							}
+						}
+
+						if len(sc.Tags) == 0 {
+							if len(secPolicy.Spec.Privilege.Tags) > 0 {
+								secPolicy.Spec.Privilege.MatchSyscalls[idx].Tags = secPolicy.Spec.Privilege.Tags
+							} else {
+								secPolicy.Spec.Privilege.MatchSyscalls[idx].Tags = secPolicy.Spec.Tags
+							}
+						}
+
+						if len(sc.Message) == 0 {
+							if len(secPolicy.Spec.Privilege.Message) > 0 {
+								secPolicy.Spec.Privilege.MatchSyscalls[idx].Message = secPolicy.Spec.Privilege.Message
+							} else {
+								secPolicy.Spec.Privilege.MatchSyscalls[idx].Message = secPolicy.Spec.Message
+							}
+						}
+
+						if len(sc.Action) == 0 {
+							if len(secPolicy.Spec.Privilege.Action) > 0 {
+								secPolicy.Spec.Privilege.MatchSyscalls[idx].Action = secPolicy.Spec.Privilege.Action
+							} else {
+								secPolicy.Spec.Privilege.MatchSyscalls[idx].Action = secPolicy.Spec.Action
+							}
+						}
+					}
+				}
+
+				if len(secPolicy.Spec.Syscalls.MatchSyscalls) > 0 {
+					for idx, sc := range secPolicy.Spec.Syscalls.MatchSyscalls {
+						if sc.Severity == 0 {
+							if secPolicy.Spec.Syscalls.Severity != 0 {
+								secPolicy.Spec.Syscalls.MatchSyscalls[idx].Severity = secPolicy.Spec.Syscalls.Severity
+							} else {
+								secPolicy.Spec.Syscalls.MatchSyscalls[idx].Severity = secPolicy.Spec.Severity
+							}
+						}
+
+						if len(sc.Tags) == 0 {
+							if len(secPolicy.Spec.Syscalls.Tags) > 0 {
+								secPolicy.Spec.Syscalls.MatchSyscalls[idx].Tags = secPolicy.Spec.Syscalls.Tags
+							} else {
+								secPolicy.Spec.Syscalls.MatchSyscalls[idx].Tags = secPolicy.Spec.Tags
+							}
+						}
+
+						if len(sc.Message) == 0 {
+							if len(secPolicy.Spec.Syscalls.Message) > 0 {
+								secPolicy.Spec.Syscalls.MatchSyscalls[idx].Message = secPolicy.Spec.Syscalls.Message
+							} else {
+								secPolicy.Spec.Syscalls.MatchSyscalls[idx].Message = secPolicy.Spec.Message
+							}
+						}
+
+						if len(sc.Action) == 0 {
+							if len(secPolicy.Spec.Syscalls.Action) > 0 {
+								secPolicy.Spec.Syscalls.MatchSyscalls[idx].Action = secPolicy.Spec.Syscalls.Action
+							} else {
+								secPolicy.Spec.Syscalls.MatchSyscalls[idx].Action = secPolicy.Spec.Action
+							}
+						}
+					}
+				}
+
 				if len(secPolicy.Spec.SELinux.MatchVolumeMounts) > 0 {
 					for idx, se := range secPolicy.Spec.SELinux.MatchVolumeMounts {
 						if se.Severity == 0 {
@@ -1036,7 +1780,11 @@ func (dm *KubeArmorDaemon) WatchSecurityPolicies() {
 
 				dm.SecurityPoliciesLock.Unlock()
 
-				dm.LogFeeder.Printf("Detected a Security Policy (%s/%s/%s)", strings.ToLower(event.Type), secPolicy.Metadata["namespaceName"], secPolicy.Metadata["policyName"])
+				if secPolicy.Metadata["namespaceName"] != "" {
+					dm.LogFeeder.Printf("Detected a Security Policy (%s/%s/%s)", strings.ToLower(event.Type), secPolicy.Metadata["namespaceName"], secPolicy.Metadata["policyName"])
+				} else {
+					dm.LogFeeder.Printf("Detected a Cluster Security Policy (%s/%s)", strings.ToLower(event.Type), secPolicy.Metadata["policyName"])
+				}
 
 				// apply security policies to containers
 				dm.UpdateSecurityPolicy(event.Type, secPolicy)
@@ -1045,6 +1793,18 @@ func (dm *KubeArmorDaemon) WatchSecurityPolicies() {
 	}
 }
 
+// MatchHostSecurityPolicy Function
+func MatchHostSecurityPolicy(secPolicy tp.HostSecurityPolicy, nodeIdentities []string) bool {
+	selector := secPolicy.Spec.NodeSelector
+
+	// a node selector with label expressions only
+	if len(selector.Identities) == 0 {
+		return len(selector.MatchExpressions) > 0 && MatchExpressions(selector.MatchExpressions, nodeIdentities)
+	}
+
+	return kl.MatchIdentities(selector.Identities, nodeIdentities) && MatchExpressions(selector.MatchExpressions, nodeIdentities)
+}
+
 // UpdateHostSecurityPolicies Function
 func (dm *KubeArmorDaemon) UpdateHostSecurityPolicies() {
 	// get node identities
@@ -1056,7 +1816,7 @@ func (dm *KubeArmorDaemon) UpdateHostSecurityPolicies() {
 	secPolicies := []tp.HostSecurityPolicy{}
 
 	for _, policy := range dm.HostSecurityPolicies {
-		if kl.MatchIdentities(policy.Spec.NodeSelector.Identities, nodeIdentities) {
+		if MatchHostSecurityPolicy(policy, nodeIdentities) {
 			secPolicies = append(secPolicies, policy)
 		}
 	}
@@ -1119,6 +1879,10 @@ func (dm *KubeArmorDaemon) WatchHostSecurityPolicies() {
 					secPolicy.Spec.Action = "Audit"
 				case "block":
 					secPolicy.Spec.Action = "Block"
+				case "kill":
+					secPolicy.Spec.Action = "Kill"
+				case "stop":
+					secPolicy.Spec.Action = "Stop"
 				case "":
 					secPolicy.Spec.Action = "Block" // by default
 				}
@@ -1237,6 +2001,42 @@ func (dm *KubeArmorDaemon) WatchHostSecurityPolicies() {
 					}
 				}
 
+				if len(secPolicy.Spec.Process.MatchFileless) > 0 {
+					for idx, fl := range secPolicy.Spec.Process.MatchFileless {
+						if fl.Severity == 0 {
+							if secPolicy.Spec.Process.Severity != 0 {
+								secPolicy.Spec.Process.MatchFileless[idx].Severity = secPolicy.Spec.Process.Severity
+							} else {
+								secPolicy.Spec.Process.MatchFileless[idx].Severity = secPolicy.Spec.Severity
+							}
+						}
+
+						if len(fl.Tags) == 0 {
+							if len(secPolicy.Spec.Process.Tags) > 0 {
+								secPolicy.Spec.Process.MatchFileless[idx].Tags = secPolicy.Spec.Process.Tags
+							} else {
+								secPolicy.Spec.Process.MatchFileless[idx].Tags = secPolicy.Spec.Tags
+							}
+						}
+
+						if len(fl.Message) == 0 {
+							if len(secPolicy.Spec.Process.Message) > 0 {
+								secPolicy.Spec.Process.MatchFileless[idx].Message = secPolicy.Spec.Process.Message
+							} else {
+								secPolicy.Spec.Process.MatchFileless[idx].Message = secPolicy.Spec.Message
+							}
+						}
+
+						if len(fl.Action) == 0 {
+							if len(secPolicy.Spec.Process.Action) > 0 {
+								secPolicy.Spec.Process.MatchFileless[idx].Action = secPolicy.Spec.Process.Action
+							} else {
+								secPolicy.Spec.Process.MatchFileless[idx].Action = secPolicy.Spec.Action
+							}
+						}
+					}
+				}
+
 				if len(secPolicy.Spec.File.MatchPaths) > 0 {
 					for idx, path := range secPolicy.Spec.File.MatchPaths {
 						if path.Severity == 0 {
@@ -1377,6 +2177,114 @@ func (dm *KubeArmorDaemon) WatchHostSecurityPolicies() {
 					}
 				}
 
+				if len(secPolicy.Spec.Network.MatchIPs) > 0 {
+					for idx, ip := range secPolicy.Spec.Network.MatchIPs {
+						if ip.Severity == 0 {
+							if secPolicy.Spec.Network.Severity != 0 {
+								secPolicy.Spec.Network.MatchIPs[idx].Severity = secPolicy.Spec.Network.Severity
+							} else {
+								secPolicy.Spec.Network.MatchIPs[idx].Severity = secPolicy.Spec.Severity
+							}
+						}
+
+						if len(ip.Tags) == 0 {
+							if len(secPolicy.Spec.Network.Tags) > 0 {
+								secPolicy.Spec.Network.MatchIPs[idx].Tags = secPolicy.Spec.Network.Tags
+							} else {
+								secPolicy.Spec.Network.MatchIPs[idx].Tags = secPolicy.Spec.Tags
+							}
+						}
+
+						if len(ip.Message) == 0 {
+							if len(secPolicy.Spec.Network.Message) > 0 {
+								secPolicy.Spec.Network.MatchIPs[idx].Message = secPolicy.Spec.Network.Message
+							} else {
+								secPolicy.Spec.Network.MatchIPs[idx].Message = secPolicy.Spec.Message
+							}
+						}
+
+						if len(ip.Action) == 0 {
+							if len(secPolicy.Spec.Network.Action) > 0 {
+								secPolicy.Spec.Network.MatchIPs[idx].Action = secPolicy.Spec.Network.Action
+							} else {
+								secPolicy.Spec.Network.MatchIPs[idx].Action = secPolicy.Spec.Action
+							}
+						}
+					}
+				}
+
+				if len(secPolicy.Spec.Network.MatchPorts) > 0 {
+					for idx, port := range secPolicy.Spec.Network.MatchPorts {
+						if port.Severity == 0 {
+							if secPolicy.Spec.Network.Severity != 0 {
+								secPolicy.Spec.Network.MatchPorts[idx].Severity = secPolicy.Spec.Network.Severity
+							} else {
+								secPolicy.Spec.Network.MatchPorts[idx].Severity = secPolicy.Spec.Severity
+							}
+						}
+
+						if len(port.Tags) == 0 {
+							if len(secPolicy.Spec.Network.Tags) > 0 {
+								secPolicy.Spec.Network.MatchPorts[idx].Tags = secPolicy.Spec.Network.Tags
+							} else {
+								secPolicy.Spec.Network.MatchPorts[idx].Tags = secPolicy.Spec.Tags
+							}
+						}
+
+						if len(port.Message) == 0 {
+							if len(secPolicy.Spec.Network.Message) > 0 {
+								secPolicy.Spec.Network.MatchPorts[idx].Message = secPolicy.Spec.Network.Message
+							} else {
+								secPolicy.Spec.Network.MatchPorts[idx].Message = secPolicy.Spec.Message
+							}
+						}
+
+						if len(port.Action) == 0 {
+							if len(secPolicy.Spec.Network.Action) > 0 {
+								secPolicy.Spec.Network.MatchPorts[idx].Action = secPolicy.Spec.Network.Action
+							} else {
+								secPolicy.Spec.Network.MatchPorts[idx].Action = secPolicy.Spec.Action
+							}
+						}
+					}
+				}
+
+				if len(secPolicy.Spec.Network.MatchDomains) > 0 {
+					for idx, domain := range secPolicy.Spec.Network.MatchDomains {
+						if domain.Severity == 0 {
+							if secPolicy.Spec.Network.Severity != 0 {
+								secPolicy.Spec.Network.MatchDomains[idx].Severity = secPolicy.Spec.Network.Severity
+							} else {
+								secPolicy.Spec.Network.MatchDomains[idx].Severity = secPolicy.Spec.Severity
+							}
+						}
+
+						if len(domain.Tags) == 0 {
+							if len(secPolicy.Spec.Network.Tags) > 0 {
+								secPolicy.Spec.Network.MatchDomains[idx].Tags = secPolicy.Spec.Network.Tags
+							} else {
+								secPolicy.Spec.Network.MatchDomains[idx].Tags = secPolicy.Spec.Tags
+							}
+						}
+
+						if len(domain.Message) == 0 {
+							if len(secPolicy.Spec.Network.Message) > 0 {
+								secPolicy.Spec.Network.MatchDomains[idx].Message = secPolicy.Spec.Network.Message
+							} else {
+								secPolicy.Spec.Network.MatchDomains[idx].Message = secPolicy.Spec.Message
+							}
+						}
+
+						if len(domain.Action) == 0 {
+							if len(secPolicy.Spec.Network.Action) > 0 {
+								secPolicy.Spec.Network.MatchDomains[idx].Action = secPolicy.Spec.Network.Action
+							} else {
+								secPolicy.Spec.Network.MatchDomains[idx].Action = secPolicy.Spec.Action
+							}
+						}
+					}
+				}
+
 				if len(secPolicy.Spec.Capabilities.MatchCapabilities) > 0 {
 					for idx, cap := range secPolicy.Spec.Capabilities.MatchCapabilities {
 						if cap.Severity == 0 {
@@ -1413,6 +2321,78 @@ func (dm *KubeArmorDaemon) WatchHostSecurityPolicies() {
 					}
 				}
 
+				if len(secPolicy.Spec.Privilege.MatchSyscalls) > 0 {
+					for idx, sc := range secPolicy.Spec.Privilege.MatchSyscalls {
+						if sc.Severity == 0 {
+							if secPolicy.Spec.Privilege.Severity != 0 {
+								secPolicy.Spec.Privilege.MatchSyscalls[idx].Severity = secPolicy.Spec.Privilege.Severity
+							} else {
+								secPolicy.Spec.Privilege.MatchSyscalls[idx].Severity = secPolicy.Spec.Severity
+							}
+						}
+
+						if len(sc.Tags) == 0 {
+							if len(secPolicy.Spec.Privilege.Tags) > 0 {
+								secPolicy.Spec.Privilege.MatchSyscalls[idx].Tags = secPolicy.Spec.Privilege.Tags
+							} else {
+								secPolicy.Spec.Privilege.MatchSyscalls[idx].Tags = secPolicy.Spec.Tags
+							}
+						}
+
+						if len(sc.Message) == 0 {
+							if len(secPolicy.Spec.Privilege.Message) > 0 {
+								secPolicy.Spec.Privilege.MatchSyscalls[idx].Message = secPolicy.Spec.Privilege.Message
+							} else {
+								secPolicy.Spec.Privilege.MatchSyscalls[idx].Message = secPolicy.Spec.Message
+							}
+						}
+
+						if len(sc.Action) == 0 {
+							if len(secPolicy.Spec.Privilege.Action) > 0 {
+								secPolicy.Spec.Privilege.MatchSyscalls[idx].Action = secPolicy.Spec.Privilege.Action
+							} else {
+								secPolicy.Spec.Privilege.MatchSyscalls[idx].Action = secPolicy.Spec.Action
+							}
+						}
+					}
+				}
+
+				if len(secPolicy.Spec.Kernel.MatchOperations) > 0 {
+					for idx, op := range secPolicy.Spec.Kernel.MatchOperations {
+						if op.Severity == 0 {
+							if secPolicy.Spec.Kernel.Severity != 0 {
+								secPolicy.Spec.Kernel.MatchOperations[idx].Severity = secPolicy.Spec.Kernel.Severity
+							} else {
+								secPolicy.Spec.Kernel.MatchOperations[idx].Severity = secPolicy.Spec.Severity
+							}
+						}
+
+						if len(op.Tags) == 0 {
+							if len(secPolicy.Spec.Kernel.Tags) > 0 {
+								secPolicy.Spec.Kernel.MatchOperations[idx].Tags = secPolicy.Spec.Kernel.Tags
+							} else {
+								secPolicy.Spec.Kernel.MatchOperations[idx].Tags = secPolicy.Spec.Tags
+							}
+						}
+
+						if len(op.Message) == 0 {
+							if len(secPolicy.Spec.Kernel.Message) > 0 {
+								secPolicy.Spec.Kernel.MatchOperations[idx].Message = secPolicy.Spec.Kernel.Message
+							} else {
+								secPolicy.Spec.Kernel.MatchOperations[idx].Message = secPolicy.Spec.Message
+							}
+						}
+
+						if len(op.Action) == 0 {
+							if len(secPolicy.Spec.Kernel.Action) > 0 {
+								secPolicy.Spec.Kernel.MatchOperations[idx].Action = secPolicy.Spec.Kernel.Action
+							} else {
+								secPolicy.Spec.Kernel.MatchOperations[idx].Action = secPolicy.Spec.Action
+							}
+						}
+					}
+				}
+
 				// update a security policy into the policy list
 
 				if event.Type == "ADDED" {
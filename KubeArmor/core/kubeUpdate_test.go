@@ -0,0 +1,129 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"sync"
+	"testing"
+
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+func TestMatchClusterPolicy(t *testing.T) {
+	untrackedNamespaces := []string{"kubearmor"}
+
+	allPolicy := tp.SecurityPolicy{Metadata: map[string]string{"policyName": "cluster-all"}}
+
+	namedPolicy := tp.SecurityPolicy{Metadata: map[string]string{"policyName": "cluster-named"}}
+	namedPolicy.Spec.NamespaceSelector.MatchNames = []string{"kube-system", "default"}
+
+	labeledPolicy := tp.SecurityPolicy{Metadata: map[string]string{"policyName": "cluster-labeled"}}
+	labeledPolicy.Spec.NamespaceSelector.Identities = []string{"env=prod"}
+
+	cases := []struct {
+		policy   tp.SecurityPolicy
+		endPoint tp.EndPoint
+		matched  bool
+	}{
+		{allPolicy, tp.EndPoint{NamespaceName: "default", Identities: []string{"app=nginx"}}, true},
+		{allPolicy, tp.EndPoint{NamespaceName: "kube-system", Identities: []string{"k8s-app=kube-dns"}}, false},
+		{allPolicy, tp.EndPoint{NamespaceName: "kubearmor", Identities: []string{"app=relay"}}, false},
+		{allPolicy, tp.EndPoint{NamespaceName: "security", Identities: []string{"kubearmor-app=kubearmor"}}, false},
+		{namedPolicy, tp.EndPoint{NamespaceName: "kube-system", Identities: []string{"k8s-app=kube-dns"}}, true},
+		{namedPolicy, tp.EndPoint{NamespaceName: "dev", Identities: []string{"app=nginx"}}, false},
+		{labeledPolicy, tp.EndPoint{NamespaceName: "default", NamespaceLabels: []string{"env=prod"}}, true},
+		{labeledPolicy, tp.EndPoint{NamespaceName: "default", NamespaceLabels: []string{"env=dev"}}, false},
+		{labeledPolicy, tp.EndPoint{NamespaceName: "kube-system", NamespaceLabels: []string{"env=prod"}}, false},
+	}
+
+	for _, c := range cases {
+		if matched := MatchSecurityPolicy(c.policy, c.endPoint, untrackedNamespaces); matched != c.matched {
+			t.Errorf("[FAIL] %s with %s/%v (expected: %v, got: %v)", c.policy.Metadata["policyName"], c.endPoint.NamespaceName, c.endPoint.Identities, c.matched, matched)
+			return
+		}
+	}
+
+	t.Log("[PASS] Matched cluster policies except the system namespaces")
+}
+
+func TestGetNamespaceLabels(t *testing.T) {
+	dm := &KubeArmorDaemon{
+		K8sNamespaces:     map[string]tp.K8sNamespace{"default": {Name: "default", Labels: map[string]string{"env": "prod"}}},
+		K8sNamespacesLock: new(sync.RWMutex),
+	}
+
+	if labels := dm.GetNamespaceLabels("default"); len(labels) != 1 || labels[0] != "env=prod" {
+		t.Errorf("[FAIL] Failed to get the labels of a namespace from the cache (%v)", labels)
+		return
+	}
+
+	t.Log("[PASS] Got the labels of a namespace from the cache")
+}
+
+func TestMatchExpressions(t *testing.T) {
+	labels := []string{"app=nginx", "env=prod", "tier="}
+
+	cases := []struct {
+		name        string
+		expressions []tp.MatchExpressionType
+		matched     bool
+	}{
+		{"no expressions", []tp.MatchExpressionType{}, true},
+		{"In", []tp.MatchExpressionType{{Key: "env", Operator: "In", Values: []string{"dev", "prod"}}}, true},
+		{"In with another value", []tp.MatchExpressionType{{Key: "env", Operator: "In", Values: []string{"dev"}}}, false},
+		{"In with a missing key", []tp.MatchExpressionType{{Key: "zone", Operator: "In", Values: []string{"a"}}}, false},
+		{"In with an empty value", []tp.MatchExpressionType{{Key: "tier", Operator: "In", Values: []string{""}}}, true},
+		{"NotIn", []tp.MatchExpressionType{{Key: "env", Operator: "NotIn", Values: []string{"dev"}}}, true},
+		{"NotIn with the value", []tp.MatchExpressionType{{Key: "env", Operator: "NotIn", Values: []string{"prod"}}}, false},
+		{"NotIn with a missing key", []tp.MatchExpressionType{{Key: "zone", Operator: "NotIn", Values: []string{"a"}}}, true},
+		{"Exists", []tp.MatchExpressionType{{Key: "tier", Operator: "Exists"}}, true},
+		{"Exists with a missing key", []tp.MatchExpressionType{{Key: "zone", Operator: "Exists"}}, false},
+		{"DoesNotExist", []tp.MatchExpressionType{{Key: "zone", Operator: "DoesNotExist"}}, true},
+		{"DoesNotExist with the key", []tp.MatchExpressionType{{Key: "app", Operator: "DoesNotExist"}}, false},
+		{"unknown operator", []tp.MatchExpressionType{{Key: "app", Operator: "Equals", Values: []string{"nginx"}}}, false},
+		{"all expressions", []tp.MatchExpressionType{{Key: "app", Operator: "Exists"}, {Key: "env", Operator: "In", Values: []string{"prod"}}}, true},
+		{"one expression not matched", []tp.MatchExpressionType{{Key: "app", Operator: "Exists"}, {Key: "env", Operator: "In", Values: []string{"dev"}}}, false},
+	}
+
+	for _, c := range cases {
+		if matched := MatchExpressions(c.expressions, labels); matched != c.matched {
+			t.Errorf("[FAIL] %s (expected: %v, got: %v)", c.name, c.matched, matched)
+			return
+		}
+	}
+
+	t.Log("[PASS] Matched label expressions")
+}
+
+func TestMatchHostSecurityPolicy(t *testing.T) {
+	nodeIdentities := []string{"kubernetes.io/hostname=node-1", "kubernetes.io/os=linux", "node-role.kubernetes.io/master="}
+
+	cases := []struct {
+		name     string
+		selector tp.NodeSelectorType
+		matched  bool
+	}{
+		{"labels", tp.NodeSelectorType{Identities: []string{"kubernetes.io/hostname=node-1"}}, true},
+		{"other labels", tp.NodeSelectorType{Identities: []string{"kubernetes.io/hostname=node-2"}}, false},
+		{"labels and expressions", tp.NodeSelectorType{Identities: []string{"kubernetes.io/os=linux"},
+			MatchExpressions: []tp.MatchExpressionType{{Key: "node-role.kubernetes.io/master", Operator: "DoesNotExist"}}}, false},
+		{"expressions only", tp.NodeSelectorType{MatchExpressions: []tp.MatchExpressionType{{Key: "kubernetes.io/hostname", Operator: "In", Values: []string{"node-1", "node-2"}}}}, true},
+		{"expressions only with NotIn", tp.NodeSelectorType{MatchExpressions: []tp.MatchExpressionType{{Key: "kubernetes.io/hostname", Operator: "NotIn", Values: []string{"node-1"}}}}, false},
+		{"expressions only with a missing key", tp.NodeSelectorType{MatchExpressions: []tp.MatchExpressionType{{Key: "zone", Operator: "NotIn", Values: []string{"a"}}}}, true},
+		{"expressions only with an unknown operator", tp.NodeSelectorType{MatchExpressions: []tp.MatchExpressionType{{Key: "kubernetes.io/os", Operator: "Gt", Values: []string{"1"}}}}, false},
+		{"empty selector", tp.NodeSelectorType{}, false},
+	}
+
+	for _, c := range cases {
+		secPolicy := tp.HostSecurityPolicy{}
+		secPolicy.Spec.NodeSelector = c.selector
+
+		if matched := MatchHostSecurityPolicy(secPolicy, nodeIdentities); matched != c.matched {
+			t.Errorf("[FAIL] %s (expected: %v, got: %v)", c.name, c.matched, matched)
+			return
+		}
+	}
+
+	t.Log("[PASS] Matched host policies with node labels and expressions")
+}
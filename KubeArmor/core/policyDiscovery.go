@@ -0,0 +1,361 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
+	fd "github.com/kubearmor/KubeArmor/KubeArmor/feeder"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+// ====================== //
+// == Policy Discovery == //
+// ====================== //
+
+// PolicyDiscoveryThreshold for collapsing the paths in the same directory into the directory
+const PolicyDiscoveryThreshold = 3
+
+// GetDiscoveredWorkload Function
+func (dm *KubeArmorDaemon) GetDiscoveredWorkload(namespaceName, podName string) (string, map[string]string) {
+	dm.K8sPodsLock.RLock()
+	defer dm.K8sPodsLock.RUnlock()
+
+	for _, pod := range dm.K8sPods {
+		if pod.Metadata["namespaceName"] == namespaceName && pod.Metadata["podName"] == podName {
+			labels := map[string]string{}
+			for k, v := range pod.Labels {
+				labels[k] = v
+			}
+
+			if workloadName, ok := pod.Metadata["workloadName"]; ok && workloadName != "" {
+				return workloadName, labels
+			}
+
+			return podName, labels
+		}
+	}
+
+	return "", nil
+}
+
+// GetDiscoveredProtocol Function
+func GetDiscoveredProtocol(resource string) string {
+	domain := ""
+	sockType := ""
+	protocol := ""
+
+	for _, field := range strings.Split(resource, " ") {
+		if strings.HasPrefix(field, "domain=") {
+			domain = strings.TrimPrefix(field, "domain=")
+		} else if strings.HasPrefix(field, "type=") {
+			sockType = strings.Split(strings.TrimPrefix(field, "type="), "|")[0]
+		} else if strings.HasPrefix(field, "protocol=") {
+			protocol = strings.TrimPrefix(field, "protocol=")
+		}
+	}
+
+	if domain != "AF_INET" && domain != "AF_INET6" {
+		return ""
+	}
+
+	if protocol == "1" || protocol == "58" {
+		return "icmp"
+	} else if sockType == "SOCK_STREAM" {
+		return "tcp"
+	} else if sockType == "SOCK_DGRAM" {
+		return "udp"
+	}
+
+	return ""
+}
+
+// addDiscoveredSource Function
+func addDiscoveredSource(resources map[string][]string, resource, source string) bool {
+	sources, ok := resources[resource]
+	if !ok {
+		sources = []string{}
+	}
+
+	// only absolute paths can be used in fromSource
+	if strings.HasPrefix(source, "/") && !kl.ContainsElement(sources, source) {
+		resources[resource] = append(sources, source)
+		return true
+	}
+
+	resources[resource] = sources
+
+	return !ok
+}
+
+// UpdateDiscoveredWorkload Function
+func (dm *KubeArmorDaemon) UpdateDiscoveredWorkload(workloads map[string]*tp.DiscoveredWorkload, log tp.Log) {
+	workloadName, labels := dm.GetDiscoveredWorkload(log.NamespaceName, log.PodName)
+	if workloadName == "" || len(labels) == 0 {
+		return
+	}
+
+	key := log.NamespaceName + "_" + workloadName
+
+	workload, ok := workloads[key]
+	if !ok {
+		workload = &tp.DiscoveredWorkload{
+			NamespaceName: log.NamespaceName,
+			WorkloadName:  workloadName,
+			Processes:     map[string][]string{},
+			Files:         map[string][]string{},
+			Protocols:     map[string][]string{},
+		}
+		workloads[key] = workload
+	}
+
+	workload.Labels = labels
+
+	updated := false
+
+	switch log.Operation {
+	case "Process":
+		if fields := strings.Fields(log.Resource); len(fields) > 0 && strings.HasPrefix(fields[0], "/") && !log.Fileless {
+			updated = addDiscoveredSource(workload.Processes, fields[0], log.Source)
+		}
+	case "File":
+		if strings.HasPrefix(log.Resource, "/") && !strings.HasPrefix(log.Resource, "/memfd:") {
+			updated = addDiscoveredSource(workload.Files, log.Resource, log.Source)
+		}
+	case "Network":
+		if protocol := GetDiscoveredProtocol(log.Resource); protocol != "" {
+			updated = addDiscoveredSource(workload.Protocols, protocol, log.Source)
+		}
+	}
+
+	if updated {
+		workload.Updated = true
+	}
+}
+
+// DiscoveredRule Structure
+type DiscoveredRule struct {
+	Resource string
+	Sources  []string
+}
+
+// CollapseDiscoveredPaths Function
+func CollapseDiscoveredPaths(resources map[string][]string) ([]DiscoveredRule, []DiscoveredRule, []DiscoveredRule) {
+	paths := map[string][]string{}
+	directories := map[string][]string{}
+	patterns := map[string][]string{}
+
+	// replace numeric components (e.g., /proc/1234/status) with globs
+
+	for path := range resources {
+		components := strings.Split(path, "/")
+
+		numeric := false
+		for idx, component := range components {
+			if _, err := strconv.Atoi(component); err == nil {
+				components[idx] = "*"
+				numeric = true
+			}
+		}
+
+		if numeric {
+			patterns[strings.Join(components, "/")] = nil
+			continue
+		}
+
+		dir := path[:strings.LastIndex(path, "/")+1]
+		directories[dir] = append(directories[dir], path)
+	}
+
+	// collapse the paths in the same directory into the directory
+
+	for dir, files := range directories {
+		if len(files) > PolicyDiscoveryThreshold {
+			sources := []string{}
+			for _, file := range files {
+				for _, source := range resources[file] {
+					if !kl.ContainsElement(sources, source) {
+						sources = append(sources, source)
+					}
+				}
+			}
+			directories[dir] = sources
+		} else {
+			for _, file := range files {
+				paths[file] = resources[file]
+			}
+			delete(directories, dir)
+		}
+	}
+
+	return getDiscoveredRules(paths), getDiscoveredRules(directories), getDiscoveredRules(patterns)
+}
+
+// getDiscoveredRules Function
+func getDiscoveredRules(resources map[string][]string) []DiscoveredRule {
+	rules := []DiscoveredRule{}
+
+	for resource, sources := range resources {
+		sortedSources := append([]string{}, sources...)
+		sort.Strings(sortedSources)
+
+		rules = append(rules, DiscoveredRule{Resource: resource, Sources: sortedSources})
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Resource < rules[j].Resource
+	})
+
+	return rules
+}
+
+// getYAMLString Function
+func getYAMLString(str string) string {
+	if str == "" || str == "true" || str == "false" || str == "null" || str == "yes" || str == "no" {
+		return strconv.Quote(str)
+	}
+
+	if _, err := strconv.ParseFloat(str, 64); err == nil {
+		return strconv.Quote(str)
+	}
+
+	for idx, c := range str {
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '/' || c == '_' || c == '.' {
+			continue
+		}
+
+		if idx > 0 && (c == '-' || c == '*') {
+			continue
+		}
+
+		return strconv.Quote(str)
+	}
+
+	return str
+}
+
+// getDiscoveredRulesYAML Function
+func getDiscoveredRulesYAML(key string, rules []DiscoveredRule) string {
+	body := ""
+
+	for _, rule := range rules {
+		body = body + "    - " + key + ": " + getYAMLString(rule.Resource) + "\n"
+
+		if len(rule.Sources) > 0 {
+			body = body + "      fromSource:\n"
+			for _, source := range rule.Sources {
+				body = body + "      - path: " + getYAMLString(source) + "\n"
+			}
+		}
+	}
+
+	return body
+}
+
+// GenerateDiscoveredPolicy Function
+func GenerateDiscoveredPolicy(workload tp.DiscoveredWorkload) string {
+	policy := "apiVersion: security.kubearmor.com/v1\n"
+	policy = policy + "kind: KubeArmorPolicy\n"
+	policy = policy + "metadata:\n"
+	policy = policy + "  name: " + getYAMLString("ksp-"+workload.WorkloadName+"-discovered") + "\n"
+	policy = policy + "  namespace: " + getYAMLString(workload.NamespaceName) + "\n"
+	policy = policy + "spec:\n"
+	policy = policy + "  severity: 1\n"
+	policy = policy + "  message: \"discovered from the observed behavior\"\n"
+	policy = policy + "  selector:\n"
+	policy = policy + "    matchLabels:\n"
+
+	labels := []string{}
+	for k := range workload.Labels {
+		labels = append(labels, k)
+	}
+	sort.Strings(labels)
+
+	for _, k := range labels {
+		policy = policy + "      " + getYAMLString(k) + ": " + getYAMLString(workload.Labels[k]) + "\n"
+	}
+
+	if len(workload.Processes) > 0 {
+		paths, directories, patterns := CollapseDiscoveredPaths(workload.Processes)
+
+		policy = policy + "  process:\n"
+		if len(paths) > 0 {
+			policy = policy + "    matchPaths:\n" + getDiscoveredRulesYAML("path", paths)
+		}
+		if len(directories) > 0 {
+			policy = policy + "    matchDirectories:\n" + getDiscoveredRulesYAML("dir", directories)
+		}
+		if len(patterns) > 0 {
+			policy = policy + "    matchPatterns:\n" + getDiscoveredRulesYAML("pattern", patterns)
+		}
+	}
+
+	if len(workload.Files) > 0 {
+		paths, directories, patterns := CollapseDiscoveredPaths(workload.Files)
+
+		policy = policy + "  file:\n"
+		if len(paths) > 0 {
+			policy = policy + "    matchPaths:\n" + getDiscoveredRulesYAML("path", paths)
+		}
+		if len(directories) > 0 {
+			policy = policy + "    matchDirectories:\n" + getDiscoveredRulesYAML("dir", directories)
+		}
+		if len(patterns) > 0 {
+			policy = policy + "    matchPatterns:\n" + getDiscoveredRulesYAML("pattern", patterns)
+		}
+	}
+
+	if len(workload.Protocols) > 0 {
+		policy = policy + "  network:\n"
+		policy = policy + "    matchProtocols:\n" + getDiscoveredRulesYAML("protocol", getDiscoveredRules(workload.Protocols))
+	}
+
+	policy = policy + "  action:\n"
+	policy = policy + "    Allow\n"
+
+	return policy
+}
+
+// WriteDiscoveredPolicies Function
+func (dm *KubeArmorDaemon) WriteDiscoveredPolicies(workloads map[string]*tp.DiscoveredWorkload) {
+	for key, workload := range workloads {
+		if !workload.Updated {
+			continue
+		}
+
+		fileName := filepath.Join(dm.PolicyDiscoveryPath, key+".yaml")
+
+		if err := ioutil.WriteFile(fileName, []byte(GenerateDiscoveredPolicy(*workload)), 0600); err != nil {
+			dm.LogFeeder.Errf("Failed to write a discovered policy (%s, %s)", fileName, err.Error())
+			continue
+		}
+
+		workload.Updated = false
+	}
+}
+
+// DiscoverSecurityPolicies Function
+func (dm *KubeArmorDaemon) DiscoverSecurityPolicies() {
+	workloads := map[string]*tp.DiscoveredWorkload{}
+
+	ticker := time.NewTicker(dm.PolicyDiscoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-StopChan:
+			return
+		case log := <-fd.DiscoveryQueue:
+			dm.UpdateDiscoveredWorkload(workloads, log)
+		case <-ticker.C:
+			dm.WriteDiscoveredPolicies(workloads)
+		}
+	}
+}
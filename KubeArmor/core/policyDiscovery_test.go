@@ -0,0 +1,81 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+func TestDiscoveredPolicy(t *testing.T) {
+	// create KubeArmorDaemon with a pod of a deployment
+	dm := new(KubeArmorDaemon)
+	dm.K8sPodsLock = new(sync.RWMutex)
+	dm.K8sPods = []tp.K8sPod{
+		{
+			Metadata: map[string]string{"namespaceName": "multiubuntu", "podName": "ubuntu-1-deployment-abcde", "workloadName": "ubuntu-1-deployment"},
+			Labels:   map[string]string{"container": "ubuntu-1", "group": "group-1"},
+		},
+	}
+
+	logs := []tp.Log{
+		{Operation: "Process", Source: "/bin/bash", Resource: "/bin/sleep 1"},
+		{Operation: "Process", Source: "/bin/bash", Resource: "/bin/sleep 2"},
+		{Operation: "File", Source: "/usr/bin/python3", Resource: "/etc/passwd"},
+		{Operation: "File", Source: "/bin/cat", Resource: "/proc/1234/status"},
+		{Operation: "File", Source: "/bin/cat", Resource: "/proc/5678/status"},
+		{Operation: "Network", Source: "/usr/bin/curl", Resource: "domain=AF_INET type=SOCK_STREAM|SOCK_CLOEXEC protocol=0"},
+		{Operation: "Network", Source: "/bin/ping", Resource: "domain=AF_INET type=SOCK_RAW protocol=1"},
+		{Operation: "Network", Source: "/bin/bash", Resource: "domain=AF_UNIX type=SOCK_STREAM protocol=0"},
+	}
+
+	for _, lib := range []string{"libc.so.6", "libm.so.6", "libdl.so.2", "libz.so.1"} {
+		logs = append(logs, tp.Log{Operation: "File", Source: "/usr/bin/python3", Resource: "/lib/x86_64-linux-gnu/" + lib})
+	}
+
+	workloads := map[string]*tp.DiscoveredWorkload{}
+	for _, log := range logs {
+		log.NamespaceName = "multiubuntu"
+		log.PodName = "ubuntu-1-deployment-abcde"
+		dm.UpdateDiscoveredWorkload(workloads, log)
+	}
+
+	workload, ok := workloads["multiubuntu_ubuntu-1-deployment"]
+	if !ok || !workload.Updated {
+		t.Error("[FAIL] Failed to aggregate the logs of the deployment")
+		return
+	}
+
+	t.Log("[PASS] Aggregated the logs of the deployment")
+
+	policy := GenerateDiscoveredPolicy(*workload)
+
+	expected := []string{
+		"  name: ksp-ubuntu-1-deployment-discovered\n",
+		"      container: ubuntu-1\n      group: group-1\n",
+		"  process:\n    matchPaths:\n    - path: /bin/sleep\n      fromSource:\n      - path: /bin/bash\n",
+		"    matchPaths:\n    - path: /etc/passwd\n      fromSource:\n      - path: /usr/bin/python3\n",
+		"    matchDirectories:\n    - dir: /lib/x86_64-linux-gnu/\n      fromSource:\n      - path: /usr/bin/python3\n",
+		"    matchPatterns:\n    - pattern: /proc/*/status\n",
+		"    matchProtocols:\n    - protocol: icmp\n      fromSource:\n      - path: /bin/ping\n    - protocol: tcp\n      fromSource:\n      - path: /usr/bin/curl\n",
+		"  action:\n    Allow\n",
+	}
+
+	for _, str := range expected {
+		if !strings.Contains(policy, str) {
+			t.Errorf("[FAIL] Failed to find %q in the discovered policy\n%s", str, policy)
+			return
+		}
+	}
+
+	if strings.Contains(policy, "libc.so.6") || strings.Contains(policy, "1234") {
+		t.Errorf("[FAIL] Failed to collapse the paths in the discovered policy\n%s", policy)
+		return
+	}
+
+	t.Log("[PASS] Generated the discovered policy")
+}
@@ -0,0 +1,183 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+	pb "github.com/kubearmor/KubeArmor/protobuf"
+)
+
+// ========================== //
+// == Process Tree Service == //
+// ========================== //
+
+// ProcessTreeService Structure
+type ProcessTreeService struct {
+	Daemon *KubeArmorDaemon
+}
+
+// convertPidNode Function
+func convertPidNode(containerID string, node tp.PidNode) *pb.ProcessNode {
+	pbNode := &pb.ProcessNode{}
+
+	pbNode.ContainerID = containerID
+
+	pbNode.HostPPID = int32(node.HostPPID)
+	pbNode.HostPID = int32(node.HostPID)
+	pbNode.PPID = int32(node.PPID)
+	pbNode.PID = int32(node.PID)
+	pbNode.UID = int32(node.UID)
+
+	pbNode.Comm = strings.TrimRight(node.Comm, "\x00")
+
+	// ExecPath in PidNode has the arguments at the end
+	pbNode.ExecPath = strings.TrimSuffix(node.ExecPath, strings.Join(append([]string{""}, node.Args...), " "))
+	pbNode.Args = append([]string{}, node.Args...)
+
+	pbNode.Exited = node.Exited
+	if node.Exited {
+		pbNode.ExitedTime = node.ExitedTime.UTC().Format(time.RFC3339Nano)
+	}
+
+	return pbNode
+}
+
+// getPidNodes Function
+func (ps *ProcessTreeService) getPidNodes(containerID string, hostPid uint32) (string, map[uint32]tp.PidNode) {
+	dm := ps.Daemon
+
+	nodes := map[uint32]tp.PidNode{}
+
+	dm.ActivePidMapLock.RLock()
+	if containerID == "" && hostPid != 0 {
+		// find the container that the given process belongs to
+		for id, pidMap := range dm.ActiveHostPidMap {
+			if _, ok := pidMap[hostPid]; ok {
+				containerID = id
+				break
+			}
+		}
+	}
+
+	if containerID != "" {
+		for pid, node := range dm.ActiveHostPidMap[containerID] {
+			nodes[pid] = node
+		}
+		dm.ActivePidMapLock.RUnlock()
+
+		return containerID, nodes
+	}
+	dm.ActivePidMapLock.RUnlock()
+
+	// host processes
+	dm.ActiveHostMapLock.RLock()
+	for pid, pidMap := range dm.ActiveHostMap {
+		if node, ok := pidMap[pid]; ok {
+			nodes[pid] = node
+		}
+	}
+	dm.ActiveHostMapLock.RUnlock()
+
+	return "", nodes
+}
+
+// buildReply Function
+func buildReply(containerID string, nodes []tp.PidNode) *pb.ProcessTreeReply {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].HostPID < nodes[j].HostPID
+	})
+
+	reply := &pb.ProcessTreeReply{Nodes: []*pb.ProcessNode{}}
+
+	for _, node := range nodes {
+		reply.Nodes = append(reply.Nodes, convertPidNode(containerID, node))
+	}
+
+	return reply
+}
+
+// ListProcesses Function
+func (ps *ProcessTreeService) ListProcesses(ctx context.Context, req *pb.ProcessTreeRequest) (*pb.ProcessTreeReply, error) {
+	containerID, pidNodes := ps.getPidNodes(req.ContainerID, 0)
+
+	nodes := []tp.PidNode{}
+	for _, node := range pidNodes {
+		nodes = append(nodes, node)
+	}
+
+	return buildReply(containerID, nodes), nil
+}
+
+// GetAncestors Function
+func (ps *ProcessTreeService) GetAncestors(ctx context.Context, req *pb.ProcessTreeRequest) (*pb.ProcessTreeReply, error) {
+	containerID, pidNodes := ps.getPidNodes(req.ContainerID, uint32(req.HostPID))
+
+	nodes := []tp.PidNode{}
+
+	node, ok := pidNodes[uint32(req.HostPID)]
+	if !ok {
+		return buildReply(containerID, nodes), nil
+	}
+
+	// follow parents until the process tree ends (the visited map prevents cycles)
+	visited := map[uint32]bool{node.HostPID: true}
+	for {
+		parent, ok := pidNodes[node.HostPPID]
+		if !ok || visited[parent.HostPID] {
+			break
+		}
+
+		nodes = append(nodes, parent)
+		visited[parent.HostPID] = true
+
+		node = parent
+	}
+
+	return buildReply(containerID, nodes), nil
+}
+
+// GetDescendants Function
+func (ps *ProcessTreeService) GetDescendants(ctx context.Context, req *pb.ProcessTreeRequest) (*pb.ProcessTreeReply, error) {
+	containerID, pidNodes := ps.getPidNodes(req.ContainerID, uint32(req.HostPID))
+
+	nodes := []tp.PidNode{}
+
+	if _, ok := pidNodes[uint32(req.HostPID)]; !ok {
+		return buildReply(containerID, nodes), nil
+	}
+
+	children := map[uint32][]tp.PidNode{}
+	for _, node := range pidNodes {
+		if node.HostPID != node.HostPPID {
+			children[node.HostPPID] = append(children[node.HostPPID], node)
+		}
+	}
+
+	// breadth-first search from the given process
+	visited := map[uint32]bool{uint32(req.HostPID): true}
+	queue := []uint32{uint32(req.HostPID)}
+
+	for len(queue) > 0 {
+		hostPid := queue[0]
+		queue = queue[1:]
+
+		for _, child := range children[hostPid] {
+			if visited[child.HostPID] {
+				continue
+			}
+
+			nodes = append(nodes, child)
+			visited[child.HostPID] = true
+
+			queue = append(queue, child.HostPID)
+		}
+	}
+
+	return buildReply(containerID, nodes), nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+	pb "github.com/kubearmor/KubeArmor/protobuf"
+)
+
+// getHostPIDs Function
+func getHostPIDs(reply *pb.ProcessTreeReply) []int32 {
+	hostPids := []int32{}
+	for _, node := range reply.Nodes {
+		hostPids = append(hostPids, node.HostPID)
+	}
+	return hostPids
+}
+
+// equalHostPIDs Function
+func equalHostPIDs(reply *pb.ProcessTreeReply, expected []int32) bool {
+	hostPids := getHostPIDs(reply)
+	if len(hostPids) != len(expected) {
+		return false
+	}
+
+	for idx := range hostPids {
+		if hostPids[idx] != expected[idx] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestProcessTreeService(t *testing.T) {
+	// set up a process tree (1000 -> 1001 -> 1002, 1001 -> 1003 (exited))
+	pidMap := tp.PidMap{
+		1000: tp.PidNode{HostPPID: 900, HostPID: 1000, PPID: 0, PID: 1, ExecPath: "/bin/bash"},
+		1001: tp.PidNode{HostPPID: 1000, HostPID: 1001, PPID: 1, PID: 2, ExecPath: "/bin/sh -c sleep", Args: []string{"-c", "sleep"}},
+		1002: tp.PidNode{HostPPID: 1001, HostPID: 1002, PPID: 2, PID: 3, ExecPath: "/bin/sleep 10", Args: []string{"10"}},
+		1003: tp.PidNode{HostPPID: 1001, HostPID: 1003, PPID: 2, PID: 4, ExecPath: "/bin/ls", Exited: true, ExitedTime: time.Now()},
+	}
+
+	dm := &KubeArmorDaemon{
+		ActivePidMap:      map[string]tp.PidMap{},
+		ActiveHostPidMap:  map[string]tp.PidMap{"container": pidMap},
+		ActivePidMapLock:  new(sync.RWMutex),
+		ActiveHostMap:     map[uint32]tp.PidMap{},
+		ActiveHostMapLock: new(sync.RWMutex),
+	}
+
+	ps := &ProcessTreeService{Daemon: dm}
+
+	// list processes
+
+	reply, err := ps.ListProcesses(context.Background(), &pb.ProcessTreeRequest{ContainerID: "container"})
+	if err != nil || !equalHostPIDs(reply, []int32{1000, 1001, 1002, 1003}) {
+		t.Errorf("[FAIL] Failed to list processes (%v)", getHostPIDs(reply))
+		return
+	}
+
+	if reply.Nodes[1].ExecPath != "/bin/sh" || len(reply.Nodes[1].Args) != 2 {
+		t.Errorf("[FAIL] Failed to split the exec path and the arguments (%s, %v)", reply.Nodes[1].ExecPath, reply.Nodes[1].Args)
+		return
+	}
+
+	if !reply.Nodes[3].Exited || reply.Nodes[3].ExitedTime == "" {
+		t.Error("[FAIL] Failed to report an exited process")
+		return
+	}
+
+	t.Log("[PASS] Listed processes")
+
+	// get ancestors (the container is found by the host pid)
+
+	reply, err = ps.GetAncestors(context.Background(), &pb.ProcessTreeRequest{HostPID: 1002})
+	if err != nil || reply.Nodes[0].ContainerID != "container" || !equalHostPIDs(reply, []int32{1000, 1001}) {
+		t.Errorf("[FAIL] Failed to get ancestors (%v)", getHostPIDs(reply))
+		return
+	}
+
+	t.Log("[PASS] Got ancestors")
+
+	// get descendants (including the exited process)
+
+	reply, err = ps.GetDescendants(context.Background(), &pb.ProcessTreeRequest{ContainerID: "container", HostPID: 1000})
+	if err != nil || !equalHostPIDs(reply, []int32{1001, 1002, 1003}) {
+		t.Errorf("[FAIL] Failed to get descendants (%v)", getHostPIDs(reply))
+		return
+	}
+
+	reply, err = ps.GetDescendants(context.Background(), &pb.ProcessTreeRequest{ContainerID: "container", HostPID: 1003})
+	if err != nil || len(reply.Nodes) != 0 {
+		t.Errorf("[FAIL] Got descendants of an exited process without children (%v)", getHostPIDs(reply))
+		return
+	}
+
+	t.Log("[PASS] Got descendants")
+
+	// unknown pids
+
+	reply, err = ps.GetAncestors(context.Background(), &pb.ProcessTreeRequest{HostPID: 4242})
+	if err != nil || len(reply.Nodes) != 0 {
+		t.Errorf("[FAIL] Got ancestors of an unknown pid (%v)", getHostPIDs(reply))
+		return
+	}
+
+	reply, err = ps.GetDescendants(context.Background(), &pb.ProcessTreeRequest{ContainerID: "container", HostPID: 4242})
+	if err != nil || len(reply.Nodes) != 0 {
+		t.Errorf("[FAIL] Got descendants of an unknown pid (%v)", getHostPIDs(reply))
+		return
+	}
+
+	reply, err = ps.ListProcesses(context.Background(), &pb.ProcessTreeRequest{ContainerID: "unknown"})
+	if err != nil || len(reply.Nodes) != 0 {
+		t.Errorf("[FAIL] Listed processes of an unknown container (%v)", getHostPIDs(reply))
+		return
+	}
+
+	t.Log("[PASS] Handled unknown pids and containers")
+}
@@ -0,0 +1,92 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"strings"
+	"time"
+
+	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
+	fd "github.com/kubearmor/KubeArmor/KubeArmor/feeder"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+// ============================== //
+// == Partial Seccomp Profiles == //
+// ============================== //
+
+// PartialSeccompProfileInterval for writing the partial seccomp profiles
+const PartialSeccompProfileInterval = 1 * time.Minute
+
+// UpdatePartialSeccompProfile Function
+func (dm *KubeArmorDaemon) UpdatePartialSeccompProfile(profiles map[string]*tp.PartialSeccompProfile, log tp.Log) {
+	if log.ContainerName == "" {
+		return
+	}
+
+	workloadName, _ := dm.GetDiscoveredWorkload(log.NamespaceName, log.PodName)
+	if workloadName == "" {
+		return
+	}
+
+	// syscall=SYS_OPENAT fd=... -> SYS_OPENAT
+	fields := strings.Fields(log.Data)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "syscall=") {
+		return
+	}
+	syscall := strings.TrimPrefix(fields[0], "syscall=")
+
+	key := log.NamespaceName + "_" + workloadName + "_" + log.ContainerName
+
+	profile, ok := profiles[key]
+	if !ok {
+		profile = &tp.PartialSeccompProfile{
+			NamespaceName: log.NamespaceName,
+			WorkloadName:  workloadName,
+			ContainerName: log.ContainerName,
+			Syscalls:      []string{},
+		}
+		profiles[key] = profile
+	}
+
+	if !kl.ContainsElement(profile.Syscalls, syscall) {
+		profile.Syscalls = append(profile.Syscalls, syscall)
+		profile.Updated = true
+	}
+}
+
+// WritePartialSeccompProfiles Function
+func (dm *KubeArmorDaemon) WritePartialSeccompProfiles(profiles map[string]*tp.PartialSeccompProfile) {
+	for _, profile := range profiles {
+		if !profile.Updated {
+			continue
+		}
+
+		if err := dm.RuntimeEnforcer.WritePartialSeccompProfile(profile.NamespaceName, profile.WorkloadName, profile.ContainerName, profile.Syscalls); err != nil {
+			dm.LogFeeder.Errf("Failed to write a partial seccomp profile (%s/%s/%s, %s)", profile.NamespaceName, profile.WorkloadName, profile.ContainerName, err.Error())
+			continue
+		}
+
+		profile.Updated = false
+	}
+}
+
+// GeneratePartialSeccompProfiles Function
+func (dm *KubeArmorDaemon) GeneratePartialSeccompProfiles() {
+	profiles := map[string]*tp.PartialSeccompProfile{}
+
+	ticker := time.NewTicker(PartialSeccompProfileInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-StopChan:
+			return
+		case log := <-fd.SeccompQueue:
+			dm.UpdatePartialSeccompProfile(profiles, log)
+		case <-ticker.C:
+			dm.WritePartialSeccompProfiles(profiles)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+func TestPartialSeccompProfile(t *testing.T) {
+	// create KubeArmorDaemon with a pod of a deployment
+	dm := new(KubeArmorDaemon)
+	dm.K8sPodsLock = new(sync.RWMutex)
+	dm.K8sPods = []tp.K8sPod{
+		{
+			Metadata: map[string]string{"namespaceName": "multiubuntu", "podName": "ubuntu-1-deployment-abcde", "workloadName": "ubuntu-1-deployment"},
+			Labels:   map[string]string{"container": "ubuntu-1", "group": "group-1"},
+		},
+	}
+
+	logs := []tp.Log{
+		{Type: "ContainerLog", ContainerName: "ubuntu-1-container", Data: "syscall=SYS_OPENAT fd=-100 flags=O_RDONLY", Result: "Passed"},
+		{Type: "ContainerLog", ContainerName: "ubuntu-1-container", Data: "syscall=SYS_EXECVE", Result: "Passed"},
+		{Type: "ContainerLog", ContainerName: "ubuntu-1-container", Data: "syscall=SYS_OPENAT fd=-100 flags=O_RDONLY", Result: "No such file or directory"},
+		{Type: "ContainerLog", ContainerName: "ubuntu-1-container", Data: "lsm=SELINUX"},
+		{Type: "ContainerLog", Data: "syscall=SYS_SOCKET"},
+	}
+
+	profiles := map[string]*tp.PartialSeccompProfile{}
+
+	for _, log := range logs {
+		log.NamespaceName = "multiubuntu"
+		log.PodName = "ubuntu-1-deployment-abcde"
+		dm.UpdatePartialSeccompProfile(profiles, log)
+	}
+
+	if len(profiles) != 1 {
+		t.Errorf("[FAIL] Unexpected number of partial seccomp profiles (%d)", len(profiles))
+		return
+	}
+
+	profile, ok := profiles["multiubuntu_ubuntu-1-deployment_ubuntu-1-container"]
+	if !ok || !profile.Updated || !reflect.DeepEqual(profile.Syscalls, []string{"SYS_OPENAT", "SYS_EXECVE"}) {
+		t.Errorf("[FAIL] Failed to collect the traced syscalls (%v)", profiles)
+		return
+	}
+
+	t.Log("[PASS] Collected the traced syscalls of a container")
+}
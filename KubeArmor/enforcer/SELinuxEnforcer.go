@@ -418,15 +418,25 @@ func (se *SELinuxEnforcer) UpdateSELinuxProfile(endPoint tp.EndPoint, seLinuxPro
 // UpdateSecurityPolicies Function
 func (se *SELinuxEnforcer) UpdateSecurityPolicies(endPoint tp.EndPoint) {
 	selinuxProfiles := []string{}
+	containerNames := map[string][]string{}
 
-	for _, seLinuxProfile := range endPoint.SELinuxProfiles {
+	for containerName, seLinuxProfile := range endPoint.SELinuxProfiles {
 		if !kl.ContainsElement(selinuxProfiles, seLinuxProfile) {
 			selinuxProfiles = append(selinuxProfiles, seLinuxProfile)
 		}
+
+		// keep the names of the containers using each profile
+		containerNames[seLinuxProfile] = append(containerNames[seLinuxProfile], containerName)
 	}
 
-	for _, selinuxProfile := range selinuxProfiles {
-		se.UpdateSELinuxProfile(endPoint, selinuxProfile, endPoint.SecurityPolicies)
+	if endPoint.PolicyEnabled == tp.KubeArmorPolicyEnabled {
+		for _, selinuxProfile := range selinuxProfiles {
+			se.UpdateSELinuxProfile(endPoint, selinuxProfile, GetContainerSecurityPolicies(endPoint.SecurityPolicies, containerNames[selinuxProfile]))
+		}
+	} else { // PolicyDisabled
+		for _, selinuxProfile := range selinuxProfiles {
+			se.UpdateSELinuxProfile(endPoint, selinuxProfile, []tp.SecurityPolicy{})
+		}
 	}
 }
 
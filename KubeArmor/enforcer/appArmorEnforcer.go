@@ -408,6 +408,7 @@ func (ae *AppArmorEnforcer) UpdateAppArmorProfile(endPoint tp.EndPoint, appArmor
 // UpdateSecurityPolicies Function
 func (ae *AppArmorEnforcer) UpdateSecurityPolicies(endPoint tp.EndPoint) {
 	appArmorProfiles := []string{}
+	containerNames := map[string][]string{}
 
 	for _, containerName := range endPoint.Containers {
 		if kl.ContainsElement([]string{"docker-default", "unconfined", "cri-containerd.apparmor.d", ""}, endPoint.AppArmorProfiles[containerName]) {
@@ -417,11 +418,14 @@ func (ae *AppArmorEnforcer) UpdateSecurityPolicies(endPoint tp.EndPoint) {
 		if !kl.ContainsElement(appArmorProfiles, endPoint.AppArmorProfiles[containerName]) {
 			appArmorProfiles = append(appArmorProfiles, endPoint.AppArmorProfiles[containerName])
 		}
+
+		// keep the names of the containers using each profile
+		containerNames[endPoint.AppArmorProfiles[containerName]] = append(containerNames[endPoint.AppArmorProfiles[containerName]], endPoint.ContainerNames[containerName])
 	}
 
 	if endPoint.PolicyEnabled == tp.KubeArmorPolicyEnabled {
 		for _, appArmorProfile := range appArmorProfiles {
-			ae.UpdateAppArmorProfile(endPoint, appArmorProfile, endPoint.SecurityPolicies)
+			ae.UpdateAppArmorProfile(endPoint, appArmorProfile, GetContainerSecurityPolicies(endPoint.SecurityPolicies, containerNames[appArmorProfile]))
 		}
 	} else { // PolicyDisabled
 		for _, appArmorProfile := range appArmorProfiles {
@@ -1109,6 +1109,94 @@ func blockedHostCapabilitiesMatchCapabilities(cap tp.CapabilitiesCapabilityType,
 	}
 }
 
+func blockedHostKernelMatchOperations(op tp.KernelOperationType, kernelBlackList *[]string, fromSources map[string][]string) {
+	// module loads and unloads are only blocked together by denying CAP_SYS_MODULE
+	if op.Operation != "ModuleLoad" && op.Operation != "ModuleUnload" {
+		return
+	}
+
+	line := "  deny capability sys_module,\n"
+
+	if len(op.FromSource) == 0 {
+		if !kl.ContainsElement(*kernelBlackList, line) {
+			*kernelBlackList = append(*kernelBlackList, line)
+		}
+		return
+	}
+
+	for _, src := range op.FromSource {
+		source := ""
+
+		if len(src.Path) > 0 {
+			source = src.Path
+			if _, ok := fromSources[source]; !ok {
+				fromSources[source] = []string{}
+			}
+		} else if len(src.Directory) > 0 {
+			if src.Recursive {
+				source = fmt.Sprintf("%s{*,**}", src.Directory)
+				if _, ok := fromSources[source]; !ok {
+					fromSources[source] = []string{}
+				}
+			} else {
+				source = fmt.Sprintf("%s*", src.Directory)
+				if _, ok := fromSources[source]; !ok {
+					fromSources[source] = []string{}
+				}
+			}
+		} else {
+			continue
+		}
+
+		if !kl.ContainsElement(fromSources[source], line) {
+			fromSources[source] = append(fromSources[source], line)
+		}
+	}
+}
+
+func blockedHostPrivilegeMatchSyscalls(sc tp.PrivilegeSyscallType, privilegeBlackList *[]string, fromSources map[string][]string) {
+	line := getPrivilegeSyscallRule(sc.Syscall)
+	if line == "" {
+		return
+	}
+
+	if len(sc.FromSource) == 0 {
+		if !kl.ContainsElement(*privilegeBlackList, line) {
+			*privilegeBlackList = append(*privilegeBlackList, line)
+		}
+		return
+	}
+
+	for _, src := range sc.FromSource {
+		source := ""
+
+		if len(src.Path) > 0 {
+			source = src.Path
+			if _, ok := fromSources[source]; !ok {
+				fromSources[source] = []string{}
+			}
+		} else if len(src.Directory) > 0 {
+			if src.Recursive {
+				source = fmt.Sprintf("%s{*,**}", src.Directory)
+				if _, ok := fromSources[source]; !ok {
+					fromSources[source] = []string{}
+				}
+			} else {
+				source = fmt.Sprintf("%s*", src.Directory)
+				if _, ok := fromSources[source]; !ok {
+					fromSources[source] = []string{}
+				}
+			}
+		} else {
+			continue
+		}
+
+		if !kl.ContainsElement(fromSources[source], line) {
+			fromSources[source] = append(fromSources[source], line)
+		}
+	}
+}
+
 // == //
 
 // GenerateHostProfileHead Function
@@ -1161,6 +1249,10 @@ func GenerateHostProfileBody(securityPolicies []tp.HostSecurityPolicy) (int, str
 	fileAuditList := []string{}
 	fileBlackList := []string{}
 
+	kernelBlackList := []string{}
+
+	privilegeBlackList := []string{}
+
 	fromSources := map[string][]string{}
 
 	nativeAppArmorRules := []string{}
@@ -1178,6 +1270,10 @@ func GenerateHostProfileBody(securityPolicies []tp.HostSecurityPolicy) (int, str
 
 		if len(secPolicy.Spec.Process.MatchPaths) > 0 {
 			for _, path := range secPolicy.Spec.Process.MatchPaths {
+				if isFeederOnlyRule(path.Action, path.MatchArgs, path.FromUser) {
+					continue
+				}
+
 				if path.Action == "Allow" {
 					allowedHostProcessMatchPaths(path, fromSources)
 				} else if path.Action == "Audit" {
@@ -1189,6 +1285,10 @@ func GenerateHostProfileBody(securityPolicies []tp.HostSecurityPolicy) (int, str
 		}
 		if len(secPolicy.Spec.Process.MatchDirectories) > 0 {
 			for _, dir := range secPolicy.Spec.Process.MatchDirectories {
+				if isFeederOnlyRule(dir.Action, dir.MatchArgs, dir.FromUser) {
+					continue
+				}
+
 				if dir.Action == "Allow" {
 					allowedHostProcessMatchDirectories(dir, fromSources)
 				} else if dir.Action == "Audit" {
@@ -1208,8 +1308,14 @@ func GenerateHostProfileBody(securityPolicies []tp.HostSecurityPolicy) (int, str
 			}
 		}
 
+		// AppArmor cannot match fileless executions (matchFileless is audited by the feeder)
+
 		if len(secPolicy.Spec.File.MatchPaths) > 0 {
 			for _, path := range secPolicy.Spec.File.MatchPaths {
+				if isFeederOnlyRule(path.Action, nil, path.FromUser) {
+					continue
+				}
+
 				if path.Action == "Allow" {
 					allowedHostFileMatchPaths(path, fromSources)
 				} else if path.Action == "Audit" {
@@ -1221,6 +1327,10 @@ func GenerateHostProfileBody(securityPolicies []tp.HostSecurityPolicy) (int, str
 		}
 		if len(secPolicy.Spec.File.MatchDirectories) > 0 {
 			for _, dir := range secPolicy.Spec.File.MatchDirectories {
+				if isFeederOnlyRule(dir.Action, nil, dir.FromUser) {
+					continue
+				}
+
 				if dir.Action == "Allow" {
 					allowedHostFileMatchDirectories(dir, fromSources)
 				} else if dir.Action == "Audit" {
@@ -1242,6 +1352,10 @@ func GenerateHostProfileBody(securityPolicies []tp.HostSecurityPolicy) (int, str
 
 		if len(secPolicy.Spec.Network.MatchProtocols) > 0 {
 			for _, proto := range secPolicy.Spec.Network.MatchProtocols {
+				if isFeederOnlyRule(proto.Action, nil, proto.FromUser) {
+					continue
+				}
+
 				if proto.Action == "Allow" {
 					allowedHostNetworkMatchProtocols(proto, fromSources)
 				} else if proto.Action == "Block" {
@@ -1250,6 +1364,8 @@ func GenerateHostProfileBody(securityPolicies []tp.HostSecurityPolicy) (int, str
 			}
 		}
 
+		// AppArmor cannot match IP addresses, ports, and domains (matchIPs, matchPorts, and matchDomains are audited by the feeder)
+
 		if len(secPolicy.Spec.Capabilities.MatchCapabilities) > 0 {
 			for _, cap := range secPolicy.Spec.Capabilities.MatchCapabilities {
 				if cap.Action == "Allow" {
@@ -1259,6 +1375,26 @@ func GenerateHostProfileBody(securityPolicies []tp.HostSecurityPolicy) (int, str
 				}
 			}
 		}
+
+		// BPF program loads cannot be blocked by AppArmor (they are audited by the feeder)
+
+		if len(secPolicy.Spec.Kernel.MatchOperations) > 0 {
+			for _, op := range secPolicy.Spec.Kernel.MatchOperations {
+				if op.Action == "Block" {
+					blockedHostKernelMatchOperations(op, &kernelBlackList, fromSources)
+				}
+			}
+		}
+
+		// setns and unshare cannot be blocked by AppArmor (they are audited by the feeder)
+
+		if len(secPolicy.Spec.Privilege.MatchSyscalls) > 0 {
+			for _, sc := range secPolicy.Spec.Privilege.MatchSyscalls {
+				if sc.Action == "Block" {
+					blockedHostPrivilegeMatchSyscalls(sc, &privilegeBlackList, fromSources)
+				}
+			}
+		}
 	}
 
 	// body
@@ -1293,6 +1429,18 @@ func GenerateHostProfileBody(securityPolicies []tp.HostSecurityPolicy) (int, str
 
 	count = count + len(fileBlackList)
 
+	for _, line := range kernelBlackList {
+		profileBody = profileBody + line
+	}
+
+	count = count + len(kernelBlackList)
+
+	for _, line := range privilegeBlackList {
+		profileBody = profileBody + line
+	}
+
+	count = count + len(privilegeBlackList)
+
 	// body - from source
 
 	bodyFromSource := ""
@@ -17,6 +17,17 @@ import (
 
 // == //
 
+func hasFromUser(user tp.MatchUserType) bool {
+	return len(user.MatchUIDs) > 0 || len(user.ExcludeUIDs) > 0
+}
+
+// isFeederOnlyRule Function
+// AppArmor cannot match arguments and UIDs, so Audit and Block rules with matchArgs or fromUser are left to the feeder.
+// Allow rules with them still allow their executables and resources, and the feeder audits the arguments and UIDs not allowed.
+func isFeederOnlyRule(action string, matchArgs []tp.MatchArgType, user tp.MatchUserType) bool {
+	return (len(matchArgs) > 0 || hasFromUser(user)) && action != "Allow"
+}
+
 func allowedProcessMatchPaths(path tp.ProcessPathType, processWhiteList *[]string, fromSources map[string][]string) {
 	if len(path.FromSource) == 0 {
 		if path.OwnerOnly {
@@ -1275,6 +1286,65 @@ func blockedCapabilitiesMatchCapabilities(cap tp.CapabilitiesCapabilityType, cap
 	}
 }
 
+func getPrivilegeSyscallRule(syscall string) string {
+	// the uid and gid families are blocked by denying CAP_SETUID and CAP_SETGID
+	switch syscall {
+	case "setuid", "setreuid", "setresuid":
+		return "  deny capability setuid,\n"
+	case "setgid", "setregid", "setresgid":
+		return "  deny capability setgid,\n"
+	case "mount":
+		return "  deny mount,\n"
+	case "ptrace":
+		return "  deny ptrace,\n"
+	default:
+		return ""
+	}
+}
+
+func blockedPrivilegeMatchSyscalls(sc tp.PrivilegeSyscallType, privilegeBlackList *[]string, fromSources map[string][]string) {
+	line := getPrivilegeSyscallRule(sc.Syscall)
+	if line == "" {
+		return
+	}
+
+	if len(sc.FromSource) == 0 {
+		if !kl.ContainsElement(*privilegeBlackList, line) {
+			*privilegeBlackList = append(*privilegeBlackList, line)
+		}
+		return
+	}
+
+	for _, src := range sc.FromSource {
+		source := ""
+
+		if len(src.Path) > 0 {
+			source = src.Path
+			if _, ok := fromSources[source]; !ok {
+				fromSources[source] = []string{}
+			}
+		} else if len(src.Directory) > 0 {
+			if src.Recursive {
+				source = fmt.Sprintf("%s{*,**}", src.Directory)
+				if _, ok := fromSources[source]; !ok {
+					fromSources[source] = []string{}
+				}
+			} else {
+				source = fmt.Sprintf("%s*", src.Directory)
+				if _, ok := fromSources[source]; !ok {
+					fromSources[source] = []string{}
+				}
+			}
+		} else {
+			continue
+		}
+
+		if !kl.ContainsElement(fromSources[source], line) {
+			fromSources[source] = append(fromSources[source], line)
+		}
+	}
+}
+
 // == //
 
 // GenerateProfileHead Function
@@ -1342,6 +1412,8 @@ func GenerateProfileBody(securityPolicies []tp.SecurityPolicy) (int, string) {
 	capabilityWhiteList := []string{}
 	capabilityBlackList := []string{}
 
+	privilegeBlackList := []string{}
+
 	fromSources := map[string][]string{}
 
 	nativeAppArmorRules := []string{}
@@ -1359,6 +1431,10 @@ func GenerateProfileBody(securityPolicies []tp.SecurityPolicy) (int, string) {
 
 		if len(secPolicy.Spec.Process.MatchPaths) > 0 {
 			for _, path := range secPolicy.Spec.Process.MatchPaths {
+				if isFeederOnlyRule(path.Action, path.MatchArgs, path.FromUser) {
+					continue
+				}
+
 				if path.Action == "Allow" {
 					allowedProcessMatchPaths(path, &processWhiteList, fromSources)
 				} else if path.Action == "Audit" {
@@ -1370,6 +1446,10 @@ func GenerateProfileBody(securityPolicies []tp.SecurityPolicy) (int, string) {
 		}
 		if len(secPolicy.Spec.Process.MatchDirectories) > 0 {
 			for _, dir := range secPolicy.Spec.Process.MatchDirectories {
+				if isFeederOnlyRule(dir.Action, dir.MatchArgs, dir.FromUser) {
+					continue
+				}
+
 				if dir.Action == "Allow" {
 					allowedProcessMatchDirectories(dir, &processWhiteList, fromSources)
 				} else if dir.Action == "Audit" {
@@ -1391,8 +1471,14 @@ func GenerateProfileBody(securityPolicies []tp.SecurityPolicy) (int, string) {
 			}
 		}
 
+		// AppArmor cannot match fileless executions (matchFileless is audited by the feeder)
+
 		if len(secPolicy.Spec.File.MatchPaths) > 0 {
 			for _, path := range secPolicy.Spec.File.MatchPaths {
+				if isFeederOnlyRule(path.Action, nil, path.FromUser) {
+					continue
+				}
+
 				if path.Action == "Allow" {
 					allowedFileMatchPaths(path, &fileWhiteList, fromSources)
 				} else if path.Action == "Audit" {
@@ -1404,6 +1490,10 @@ func GenerateProfileBody(securityPolicies []tp.SecurityPolicy) (int, string) {
 		}
 		if len(secPolicy.Spec.File.MatchDirectories) > 0 {
 			for _, dir := range secPolicy.Spec.File.MatchDirectories {
+				if isFeederOnlyRule(dir.Action, nil, dir.FromUser) {
+					continue
+				}
+
 				if dir.Action == "Allow" {
 					allowedFileMatchDirectories(dir, &fileWhiteList, fromSources)
 				} else if dir.Action == "Audit" {
@@ -1427,6 +1517,10 @@ func GenerateProfileBody(securityPolicies []tp.SecurityPolicy) (int, string) {
 
 		if len(secPolicy.Spec.Network.MatchProtocols) > 0 {
 			for _, proto := range secPolicy.Spec.Network.MatchProtocols {
+				if isFeederOnlyRule(proto.Action, nil, proto.FromUser) {
+					continue
+				}
+
 				if proto.Action == "Allow" {
 					allowedNetworkMatchProtocols(proto, &networkWhiteList, fromSources)
 				} else if proto.Action == "Block" {
@@ -1435,6 +1529,8 @@ func GenerateProfileBody(securityPolicies []tp.SecurityPolicy) (int, string) {
 			}
 		}
 
+		// AppArmor cannot match IP addresses, ports, and domains (matchIPs, matchPorts, and matchDomains are audited by the feeder)
+
 		if len(secPolicy.Spec.Capabilities.MatchCapabilities) > 0 {
 			for _, cap := range secPolicy.Spec.Capabilities.MatchCapabilities {
 				if cap.Action == "Allow" {
@@ -1444,6 +1540,16 @@ func GenerateProfileBody(securityPolicies []tp.SecurityPolicy) (int, string) {
 				}
 			}
 		}
+
+		// setns and unshare cannot be blocked by AppArmor (they are audited by the feeder)
+
+		if len(secPolicy.Spec.Privilege.MatchSyscalls) > 0 {
+			for _, sc := range secPolicy.Spec.Privilege.MatchSyscalls {
+				if sc.Action == "Block" {
+					blockedPrivilegeMatchSyscalls(sc, &privilegeBlackList, fromSources)
+				}
+			}
+		}
 	}
 
 	// head
@@ -1520,6 +1626,12 @@ func GenerateProfileBody(securityPolicies []tp.SecurityPolicy) (int, string) {
 
 	count = count + len(capabilityBlackList)
 
+	for _, line := range privilegeBlackList {
+		profileBody = profileBody + line
+	}
+
+	count = count + len(privilegeBlackList)
+
 	// body - from source
 
 	bodyFromSource := ""
@@ -27,6 +27,9 @@ type RuntimeEnforcer struct {
 	// LSMs
 	appArmorEnforcer *AppArmorEnforcer
 	seLinuxEnforcer  *SELinuxEnforcer
+
+	// seccomp (independent of LSMs)
+	seccompEnforcer *SeccompEnforcer
 }
 
 // NewRuntimeEnforcer Function
@@ -36,6 +39,11 @@ func NewRuntimeEnforcer(feeder *fd.Feeder, enableHostPolicy bool) *RuntimeEnforc
 	re.LogFeeder = feeder
 	re.enableLSM = false
 
+	re.seccompEnforcer = NewSeccompEnforcer(feeder)
+	if re.seccompEnforcer != nil {
+		re.LogFeeder.Print("Initialized Seccomp Enforcer")
+	}
+
 	if !kl.IsK8sLocal() {
 		// mount securityfs
 		if err := kl.RunCommandAndWaitWithErr("mount", []string{"-t", "securityfs", "securityfs", "/sys/kernel/security"}); err != nil {
@@ -119,6 +127,42 @@ func (re *RuntimeEnforcer) UpdateSecurityPolicies(endPoint tp.EndPoint) {
 	if strings.Contains(re.enforcerType, "selinux") {
 		re.seLinuxEnforcer.UpdateSecurityPolicies(endPoint)
 	}
+
+	if re.seccompEnforcer != nil {
+		re.seccompEnforcer.UpdateSecurityPolicies(endPoint)
+	}
+}
+
+// WritePartialSeccompProfile Function
+func (re *RuntimeEnforcer) WritePartialSeccompProfile(namespaceName, workloadName, containerName string, syscalls []string) error {
+	// skip if seccomp is not supported
+	if re.seccompEnforcer == nil {
+		return nil
+	}
+
+	return re.seccompEnforcer.WritePartialSeccompProfile(namespaceName, workloadName, containerName, syscalls)
+}
+
+// GetContainerSecurityPolicies Function
+func GetContainerSecurityPolicies(secPolicies []tp.SecurityPolicy, containerNames []string) []tp.SecurityPolicy {
+	containerPolicies := []tp.SecurityPolicy{}
+
+	for _, secPolicy := range secPolicies {
+		// policies without containers are applied to all containers in a pod
+		if len(secPolicy.Spec.Selector.Containers) == 0 {
+			containerPolicies = append(containerPolicies, secPolicy)
+			continue
+		}
+
+		for _, containerName := range containerNames {
+			if kl.ContainsElement(secPolicy.Spec.Selector.Containers, containerName) {
+				containerPolicies = append(containerPolicies, secPolicy)
+				break
+			}
+		}
+	}
+
+	return containerPolicies
 }
 
 // UpdateHostSecurityPolicies Function
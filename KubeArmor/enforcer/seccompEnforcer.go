@@ -0,0 +1,270 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package enforcer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
+	fd "github.com/kubearmor/KubeArmor/KubeArmor/feeder"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+// ====================== //
+// == Seccomp Enforcer == //
+// ====================== //
+
+// SeccompProfileDir for the seccomp profiles of the kubelet (localhost profiles are relative to this directory)
+const SeccompProfileDir = "/var/lib/kubelet/seccomp"
+
+// SeccompProfilePrefix for the seccomp profiles managed by KubeArmor
+const SeccompProfilePrefix = "kubearmor/"
+
+// seccompSyscallName for the names of syscalls in seccomp profiles
+var seccompSyscallName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// SeccompEnforcer Structure
+type SeccompEnforcer struct {
+	// logs
+	Logger *fd.Feeder
+
+	// the directory of seccomp profiles
+	SeccompProfileDir string
+}
+
+// NewSeccompEnforcer Function
+func NewSeccompEnforcer(feeder *fd.Feeder) *SeccompEnforcer {
+	se := &SeccompEnforcer{}
+
+	se.Logger = feeder
+	se.SeccompProfileDir = SeccompProfileDir
+
+	// check if seccomp is supported by the kernel
+	status, err := ioutil.ReadFile("/proc/self/status")
+	if err != nil || !strings.Contains(string(status), "Seccomp:") {
+		se.Logger.Print("Failed to find seccomp in /proc/self/status")
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Join(se.SeccompProfileDir, SeccompProfilePrefix), 0750); err != nil {
+		se.Logger.Errf("Failed to create %s (%s)", filepath.Join(se.SeccompProfileDir, SeccompProfilePrefix), err.Error())
+		return nil
+	}
+
+	return se
+}
+
+// GetSeccompArchitectures Function
+func GetSeccompArchitectures() []string {
+	switch runtime.GOARCH {
+	case "amd64":
+		return []string{"SCMP_ARCH_X86_64", "SCMP_ARCH_X86", "SCMP_ARCH_X32"}
+	case "arm64":
+		return []string{"SCMP_ARCH_AARCH64", "SCMP_ARCH_ARM"}
+	default:
+		return []string{}
+	}
+}
+
+// GetSeccompSyscallName Function
+func GetSeccompSyscallName(syscall string) string {
+	// SYS_EXECVE (from the system monitor) -> execve
+	syscall = strings.ToLower(strings.TrimPrefix(syscall, "SYS_"))
+
+	if !seccompSyscallName.MatchString(syscall) {
+		return ""
+	}
+
+	return syscall
+}
+
+// GenerateSeccompProfile Function
+func GenerateSeccompProfile(secPolicies []tp.SecurityPolicy, policyEnabled int) tp.SeccompProfile {
+	allowSyscalls := []string{}
+	auditSyscalls := []string{}
+	blockSyscalls := []string{}
+
+	if policyEnabled == tp.KubeArmorPolicyEnabled || policyEnabled == tp.KubeArmorPolicyAudited {
+		for _, secPolicy := range secPolicies {
+			// Kill and Stop are handled as Block (no response actions for syscalls)
+			for _, sc := range secPolicy.Spec.Syscalls.MatchSyscalls {
+				syscall := GetSeccompSyscallName(sc.Syscall)
+				if syscall == "" {
+					continue
+				}
+
+				if sc.Action == "Allow" && !kl.ContainsElement(allowSyscalls, syscall) {
+					allowSyscalls = append(allowSyscalls, syscall)
+				} else if sc.Action == "Audit" && !kl.ContainsElement(auditSyscalls, syscall) {
+					auditSyscalls = append(auditSyscalls, syscall)
+				} else if (sc.Action == "Block" || sc.Action == "Kill" || sc.Action == "Stop") && !kl.ContainsElement(blockSyscalls, syscall) {
+					blockSyscalls = append(blockSyscalls, syscall)
+				}
+			}
+		}
+	}
+
+	// audited pods only log the syscalls to block
+	if policyEnabled == tp.KubeArmorPolicyAudited {
+		for _, syscall := range blockSyscalls {
+			if !kl.ContainsElement(auditSyscalls, syscall) {
+				auditSyscalls = append(auditSyscalls, syscall)
+			}
+		}
+		blockSyscalls = []string{}
+	}
+
+	profile := tp.SeccompProfile{
+		DefaultAction: "SCMP_ACT_ALLOW",
+		Architectures: GetSeccompArchitectures(),
+		Syscalls:      []tp.SeccompSyscall{},
+	}
+
+	// if there are syscalls to allow, deny (or log) all the others
+	if len(allowSyscalls) > 0 {
+		if policyEnabled == tp.KubeArmorPolicyAudited {
+			profile.DefaultAction = "SCMP_ACT_LOG"
+		} else {
+			profile.DefaultAction = "SCMP_ACT_ERRNO"
+		}
+	}
+
+	// one action per syscall (block > audit > allow)
+	blockSyscalls = getSeccompSyscallNames(blockSyscalls, []string{})
+	auditSyscalls = getSeccompSyscallNames(auditSyscalls, blockSyscalls)
+	allowSyscalls = getSeccompSyscallNames(allowSyscalls, append(append([]string{}, blockSyscalls...), auditSyscalls...))
+
+	if len(blockSyscalls) > 0 && profile.DefaultAction != "SCMP_ACT_ERRNO" {
+		profile.Syscalls = append(profile.Syscalls, tp.SeccompSyscall{Names: blockSyscalls, Action: "SCMP_ACT_ERRNO", ErrnoRet: 1})
+	}
+
+	if len(auditSyscalls) > 0 && profile.DefaultAction != "SCMP_ACT_LOG" {
+		profile.Syscalls = append(profile.Syscalls, tp.SeccompSyscall{Names: auditSyscalls, Action: "SCMP_ACT_LOG"})
+	}
+
+	if len(allowSyscalls) > 0 && profile.DefaultAction != "SCMP_ACT_ALLOW" {
+		profile.Syscalls = append(profile.Syscalls, tp.SeccompSyscall{Names: allowSyscalls, Action: "SCMP_ACT_ALLOW"})
+	}
+
+	return profile
+}
+
+// GeneratePartialSeccompProfile Function
+func GeneratePartialSeccompProfile(syscalls []string) tp.SeccompProfile {
+	tracedSyscalls := []string{}
+
+	for _, syscall := range syscalls {
+		if syscall = GetSeccompSyscallName(syscall); syscall != "" && !kl.ContainsElement(tracedSyscalls, syscall) {
+			tracedSyscalls = append(tracedSyscalls, syscall)
+		}
+	}
+
+	// only the syscalls traced by the system monitor are known, so the other syscalls are logged rather than denied
+	profile := tp.SeccompProfile{
+		DefaultAction: "SCMP_ACT_LOG",
+		Architectures: GetSeccompArchitectures(),
+		Syscalls:      []tp.SeccompSyscall{},
+	}
+
+	if names := getSeccompSyscallNames(tracedSyscalls, []string{}); len(names) > 0 {
+		profile.Syscalls = append(profile.Syscalls, tp.SeccompSyscall{Names: names, Action: "SCMP_ACT_ALLOW"})
+	}
+
+	return profile
+}
+
+// getSeccompSyscallNames Function
+func getSeccompSyscallNames(syscalls, excludedSyscalls []string) []string {
+	names := []string{}
+
+	for _, syscall := range syscalls {
+		if !kl.ContainsElement(excludedSyscalls, syscall) {
+			names = append(names, syscall)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// WriteSeccompProfile Function
+func (se *SeccompEnforcer) WriteSeccompProfile(profileName string, profile tp.SeccompProfile) error {
+	// only write the profiles under the directory for KubeArmor
+	profilePath := filepath.Join(se.SeccompProfileDir, filepath.Clean("/"+profileName))
+	if !strings.HasPrefix(profilePath, filepath.Join(se.SeccompProfileDir, SeccompProfilePrefix)+"/") {
+		return fmt.Errorf("invalid seccomp profile (%s)", profileName)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(profilePath), 0750); err != nil {
+		return err
+	}
+
+	newProfile, err := json.MarshalIndent(profile, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	// skip the profile not changed
+	if oldProfile, err := ioutil.ReadFile(filepath.Clean(profilePath)); err == nil && string(oldProfile) == string(newProfile) {
+		return nil
+	}
+
+	// write a temporary file first not to expose a partial profile to the kubelet
+	if err := ioutil.WriteFile(profilePath+".tmp", newProfile, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(profilePath+".tmp", profilePath)
+}
+
+// ================================= //
+// == Security Policy Enforcement == //
+// ================================= //
+
+// UpdateSecurityPolicies Function
+func (se *SeccompEnforcer) UpdateSecurityPolicies(endPoint tp.EndPoint) {
+	seccompProfiles := []string{}
+	containerNames := map[string][]string{}
+
+	for containerName, seccompProfile := range endPoint.SeccompProfiles {
+		if !kl.ContainsElement(seccompProfiles, seccompProfile) {
+			seccompProfiles = append(seccompProfiles, seccompProfile)
+		}
+
+		// keep the names of the containers using each profile
+		containerNames[seccompProfile] = append(containerNames[seccompProfile], containerName)
+	}
+
+	for _, seccompProfile := range seccompProfiles {
+		secPolicies := GetContainerSecurityPolicies(endPoint.SecurityPolicies, containerNames[seccompProfile])
+		profile := GenerateSeccompProfile(secPolicies, endPoint.PolicyEnabled)
+
+		if err := se.WriteSeccompProfile(seccompProfile, profile); err != nil {
+			se.Logger.Errf("Failed to update a seccomp profile (%s/%s/%s, %s)", endPoint.NamespaceName, endPoint.EndPointName, seccompProfile, err.Error())
+			continue
+		}
+
+		ruleCount := 0
+		for _, syscall := range profile.Syscalls {
+			ruleCount = ruleCount + len(syscall.Names)
+		}
+
+		se.Logger.Printf("Updated %d syscall rule(s) to %s/%s/%s", ruleCount, endPoint.NamespaceName, endPoint.EndPointName, seccompProfile)
+	}
+}
+
+// WritePartialSeccompProfile Function
+func (se *SeccompEnforcer) WritePartialSeccompProfile(namespaceName, workloadName, containerName string, syscalls []string) error {
+	profileName := SeccompProfilePrefix + "partial/kubearmor-" + namespaceName + "-" + workloadName + "-" + containerName + ".json"
+	return se.WriteSeccompProfile(profileName, GeneratePartialSeccompProfile(syscalls))
+}
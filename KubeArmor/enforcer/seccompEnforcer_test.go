@@ -0,0 +1,153 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package enforcer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	fd "github.com/kubearmor/KubeArmor/KubeArmor/feeder"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+func TestSeccompProfile(t *testing.T) {
+	secPolicies := []tp.SecurityPolicy{
+		{
+			Spec: tp.SecuritySpec{
+				Syscalls: tp.SyscallsType{
+					MatchSyscalls: []tp.SyscallsSyscallType{
+						{Syscall: "ptrace", Action: "Block"},
+						{Syscall: "unshare", Action: "Block"},
+						{Syscall: "mount", Action: "Audit"},
+						{Syscall: "ptrace", Action: "Audit"},
+						{Syscall: "INVALID SYSCALL", Action: "Block"},
+					},
+				},
+			},
+		},
+	}
+
+	// enabled pod
+
+	profile := GenerateSeccompProfile(secPolicies, tp.KubeArmorPolicyEnabled)
+	expected := []tp.SeccompSyscall{
+		{Names: []string{"ptrace", "unshare"}, Action: "SCMP_ACT_ERRNO", ErrnoRet: 1},
+		{Names: []string{"mount"}, Action: "SCMP_ACT_LOG"},
+	}
+
+	if profile.DefaultAction != "SCMP_ACT_ALLOW" || !reflect.DeepEqual(profile.Syscalls, expected) {
+		t.Errorf("[FAIL] Unexpected seccomp profile (%v)", profile)
+		return
+	}
+
+	t.Log("[PASS] Generated a seccomp profile")
+
+	// audited pod
+
+	profile = GenerateSeccompProfile(secPolicies, tp.KubeArmorPolicyAudited)
+	expected = []tp.SeccompSyscall{
+		{Names: []string{"mount", "ptrace", "unshare"}, Action: "SCMP_ACT_LOG"},
+	}
+
+	if profile.DefaultAction != "SCMP_ACT_ALLOW" || !reflect.DeepEqual(profile.Syscalls, expected) {
+		t.Errorf("[FAIL] Unexpected seccomp profile of an audited pod (%v)", profile)
+		return
+	}
+
+	t.Log("[PASS] Generated a seccomp profile of an audited pod")
+
+	// disabled pod
+
+	profile = GenerateSeccompProfile(secPolicies, tp.KubeArmorPolicyDisabled)
+	if profile.DefaultAction != "SCMP_ACT_ALLOW" || len(profile.Syscalls) > 0 {
+		t.Errorf("[FAIL] Unexpected seccomp profile of a disabled pod (%v)", profile)
+		return
+	}
+
+	t.Log("[PASS] Generated a seccomp profile of a disabled pod")
+
+	// allowed syscalls
+
+	secPolicies[0].Spec.Syscalls.MatchSyscalls = append(secPolicies[0].Spec.Syscalls.MatchSyscalls, tp.SyscallsSyscallType{Syscall: "read", Action: "Allow"})
+
+	profile = GenerateSeccompProfile(secPolicies, tp.KubeArmorPolicyEnabled)
+	expected = []tp.SeccompSyscall{
+		{Names: []string{"mount"}, Action: "SCMP_ACT_LOG"},
+		{Names: []string{"read"}, Action: "SCMP_ACT_ALLOW"},
+	}
+
+	if profile.DefaultAction != "SCMP_ACT_ERRNO" || !reflect.DeepEqual(profile.Syscalls, expected) {
+		t.Errorf("[FAIL] Unexpected seccomp profile with allowed syscalls (%v)", profile)
+		return
+	}
+
+	t.Log("[PASS] Generated a seccomp profile with allowed syscalls")
+
+	// traced syscalls
+
+	profile = GeneratePartialSeccompProfile([]string{"SYS_OPENAT", "SYS_EXECVE", "SYS_OPENAT"})
+	expected = []tp.SeccompSyscall{
+		{Names: []string{"execve", "openat"}, Action: "SCMP_ACT_ALLOW"},
+	}
+
+	if profile.DefaultAction != "SCMP_ACT_LOG" || !reflect.DeepEqual(profile.Syscalls, expected) {
+		t.Errorf("[FAIL] Unexpected partial seccomp profile (%v)", profile)
+		return
+	}
+
+	t.Log("[PASS] Generated a partial seccomp profile")
+}
+
+func TestWriteSeccompProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "seccomp")
+	if err != nil {
+		t.Log("Failed to create a temporary directory")
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	// Create Feeder
+	logFeeder := fd.NewFeeder("Default", "32767", "none", "policy", false)
+	if logFeeder == nil {
+		t.Log("[FAIL] Failed to create Feeder")
+		return
+	}
+	defer logFeeder.DestroyFeeder()
+
+	enforcer := &SeccompEnforcer{Logger: logFeeder, SeccompProfileDir: dir}
+
+	// write a profile
+
+	if err := enforcer.WritePartialSeccompProfile("multiubuntu", "ubuntu-1-deployment", "ubuntu-1-container", []string{"SYS_EXECVE"}); err != nil {
+		t.Errorf("[FAIL] Failed to write a seccomp profile (%s)", err.Error())
+		return
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "kubearmor/partial/kubearmor-multiubuntu-ubuntu-1-deployment-ubuntu-1-container.json"))
+	if err != nil {
+		t.Errorf("[FAIL] Failed to read the seccomp profile (%s)", err.Error())
+		return
+	}
+
+	profile := tp.SeccompProfile{}
+	if err := json.Unmarshal(data, &profile); err != nil || profile.DefaultAction != "SCMP_ACT_LOG" {
+		t.Error("[FAIL] Failed to parse the seccomp profile")
+		return
+	}
+
+	t.Log("[PASS] Wrote a seccomp profile")
+
+	// write a profile outside of the directory for KubeArmor
+
+	if err := enforcer.WriteSeccompProfile("kubearmor/../default.json", profile); err == nil {
+		t.Error("[FAIL] Wrote a seccomp profile outside of the directory for KubeArmor")
+		return
+	}
+
+	t.Log("[PASS] Rejected a seccomp profile outside of the directory for KubeArmor")
+}
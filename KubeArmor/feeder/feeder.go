@@ -12,8 +12,10 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
@@ -41,12 +43,40 @@ var AlertQueue chan pb.Alert
 // LogQueue for Logs
 var LogQueue chan pb.Log
 
+// QuarantineQueue for the logs to quarantine pods
+var QuarantineQueue chan tp.Log
+
+// EventQueue for the alerts to report as Kubernetes events
+var EventQueue chan tp.Log
+
+// DiscoveryQueue for the logs to discover security policies
+var DiscoveryQueue chan tp.Log
+
+// SeccompQueue for the logs to generate partial seccomp profiles
+var SeccompQueue chan tp.Log
+
+// ResponseLimit for the number of response actions per policy and endpoint in ResponseInterval
+const ResponseLimit = 5
+
+// ResponseInterval for rate-limiting response actions
+const ResponseInterval = time.Minute
+
+// ClockTicks for the start times of processes in /proc/[pid]/stat (USER_HZ)
+const ClockTicks = 100
+
+// StartTimeSlack for the resolution of the start times of processes and the uptime
+const StartTimeSlack = 20 * time.Millisecond
+
 func init() {
 	Running = true
 
 	MsgQueue = make(chan pb.Message, 1024)
 	AlertQueue = make(chan pb.Alert, 4096)
 	LogQueue = make(chan pb.Log, 32768)
+	QuarantineQueue = make(chan tp.Log, 1024)
+	EventQueue = make(chan tp.Log, 4096)
+	DiscoveryQueue = make(chan tp.Log, 4096)
+	SeccompQueue = make(chan tp.Log, 4096)
 }
 
 // ========== //
@@ -296,6 +326,19 @@ type Feeder struct {
 
 	// GKE
 	IsGKE bool
+
+	// report the alerts of pods as Kubernetes events
+	EnableK8sEvents bool
+
+	// collect the logs of pods to discover security policies
+	EnablePolicyDiscovery bool
+
+	// collect the syscalls of pods to generate partial seccomp profiles
+	EnablePartialSeccompProfiles bool
+
+	// policy name + namespace name + endpoint name / host name -> the times of response actions
+	ResponseHistory     map[string][]time.Time
+	ResponseHistoryLock *sync.Mutex
 }
 
 // NewFeeder Function
@@ -374,6 +417,10 @@ func NewFeeder(clusterName, port, output, filter string, enableHostPolicy bool)
 	fd.SecurityPolicies = map[string]tp.MatchPolicies{}
 	fd.SecurityPoliciesLock = new(sync.RWMutex)
 
+	// initialize response history
+	fd.ResponseHistory = map[string][]time.Time{}
+	fd.ResponseHistoryLock = &sync.Mutex{}
+
 	// set KubeArmorHostPolicyEnabled
 	if enableHostPolicy {
 		fd.HostPolicyEnabled = tp.KubeArmorPolicyEnabled
@@ -523,6 +570,141 @@ func (fd *Feeder) PushMessage(level, message string) {
 	MsgQueue <- pbMsg
 }
 
+// ====================== //
+// == Response Actions == //
+// ====================== //
+
+// allowResponse Function
+func (fd *Feeder) allowResponse(key string) bool {
+	fd.ResponseHistoryLock.Lock()
+	defer fd.ResponseHistoryLock.Unlock()
+
+	now := time.Now()
+
+	// keep the response actions in the last interval only (and drop the histories of the others)
+	for k, times := range fd.ResponseHistory {
+		history := []time.Time{}
+		for _, t := range times {
+			if now.Sub(t) < ResponseInterval {
+				history = append(history, t)
+			}
+		}
+
+		if len(history) == 0 {
+			delete(fd.ResponseHistory, k)
+		} else {
+			fd.ResponseHistory[k] = history
+		}
+	}
+
+	if len(fd.ResponseHistory[key]) >= ResponseLimit {
+		return false
+	}
+
+	fd.ResponseHistory[key] = append(fd.ResponseHistory[key], now)
+
+	return true
+}
+
+// getProcessStartTime Function
+func getProcessStartTime(pid int32) (time.Time, error) {
+	stat, err := ioutil.ReadFile(filepath.Clean("/proc/" + strconv.Itoa(int(pid)) + "/stat"))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// the fields after the command name (which may have spaces), starting from the state (the 3rd field)
+	idx := strings.LastIndexByte(string(stat), ')')
+	if idx < 0 {
+		return time.Time{}, fmt.Errorf("invalid stat of PID %d", pid)
+	}
+
+	fields := strings.Fields(string(stat[idx+1:]))
+	if len(fields) < 20 {
+		return time.Time{}, fmt.Errorf("invalid stat of PID %d", pid)
+	}
+
+	// the start time (the 22nd field) in clock ticks after boot
+	startTicks, err := strconv.ParseUint(fields[19], 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	uptime, err := ioutil.ReadFile("/proc/uptime")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	uptimeSec, err := strconv.ParseFloat(strings.Fields(string(uptime))[0], 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	age := uptimeSec - float64(startTicks)/ClockTicks
+
+	return time.Now().Add(-time.Duration(age * float64(time.Second))), nil
+}
+
+// isSameProcess Function
+func isSameProcess(log tp.Log) (bool, string) {
+	startTime, err := getProcessStartTime(log.HostPID)
+	if err != nil {
+		return false, "exited"
+	}
+
+	logTime, err := time.Parse(kl.TimeFormUTC, log.UpdatedTime)
+	if err != nil {
+		return false, "unknown time"
+	}
+
+	// the process of the log started before the log, while a process reusing its PID started after it
+	if startTime.After(logTime.Add(StartTimeSlack)) {
+		return false, "PID reused"
+	}
+
+	return true, ""
+}
+
+// RespondToLog Function
+func (fd *Feeder) RespondToLog(log tp.Log) tp.Log {
+	sig, result := syscall.SIGKILL, "Killed"
+	if log.Action == "Stop" {
+		sig, result = syscall.SIGSTOP, "Stopped"
+	}
+
+	// never signal init or KubeArmor itself
+	if log.HostPID <= 1 || int(log.HostPID) == os.Getpid() {
+		log.Response = "Skipped (PID " + strconv.Itoa(int(log.HostPID)) + ")"
+		return log
+	}
+
+	// the process may have exited and its PID may have been reused until now
+	if ok, reason := isSameProcess(log); !ok {
+		log.Response = "Skipped (" + reason + ")"
+		return log
+	}
+
+	key := log.PolicyName + "_" + fd.HostName
+	if log.NamespaceName != "" && log.PodName != "" {
+		key = log.PolicyName + "_" + log.NamespaceName + "_" + log.PodName
+	}
+
+	// avoid kill loops (e.g., a restarted container hitting the same policy again)
+	if !fd.allowResponse(key) {
+		log.Action = "Audit (" + log.Action + ")"
+		log.Response = "Rate limited"
+		return log
+	}
+
+	if err := syscall.Kill(int(log.HostPID), sig); err != nil {
+		log.Response = "Failed (" + err.Error() + ")"
+	} else {
+		log.Response = result
+	}
+
+	return log
+}
+
 // PushLog Function
 func (fd *Feeder) PushLog(log tp.Log) {
 	log = fd.UpdateMatchedPolicy(log)
@@ -531,6 +713,44 @@ func (fd *Feeder) PushLog(log tp.Log) {
 		return
 	}
 
+	// kill or stop the process matched with a policy
+	if log.Action == "Kill" || log.Action == "Stop" {
+		log = fd.RespondToLog(log)
+	}
+
+	// report the alert of a pod as a Kubernetes event (drop it if the queue is full)
+	if fd.EnableK8sEvents && log.Type == "MatchedPolicy" && log.PodName != "" {
+		select {
+		case EventQueue <- log:
+		default:
+		}
+	}
+
+	// collect the observed behavior of a pod (drop it if the queue is full)
+	if fd.EnablePolicyDiscovery && log.Type == "ContainerLog" && log.PodName != "" && log.Result == "Passed" {
+		select {
+		case DiscoveryQueue <- log:
+		default:
+		}
+	}
+
+	// collect the syscall of a pod, whether or not it failed (drop it if the queue is full)
+	if fd.EnablePartialSeccompProfiles && (log.Type == "ContainerLog" || log.Type == "MatchedPolicy") && log.PodName != "" && strings.HasPrefix(log.Data, "syscall=") {
+		select {
+		case SeccompQueue <- log:
+		default:
+		}
+	}
+
+	// quarantine the pod matched with a policy (only if the policy of the pod is enabled)
+	if log.Type == "MatchedPolicy" && len(log.QuarantineActions) > 0 && log.PolicyEnabled == tp.KubeArmorPolicyEnabled {
+		select {
+		case QuarantineQueue <- log:
+		default:
+			fd.Errf("Failed to quarantine a pod (%s/%s, the queue is full)", log.NamespaceName, log.PodName)
+		}
+	}
+
 	// remove visibility flags
 
 	log.PolicyEnabled = 0
@@ -628,6 +848,11 @@ func (fd *Feeder) PushLog(log tp.Log) {
 		}
 
 		pbAlert.Result = log.Result
+		pbAlert.Fileless = log.Fileless
+
+		if len(log.Response) > 0 {
+			pbAlert.Response = log.Response
+		}
 
 		AlertQueue <- pbAlert
 	} else { // ContainerLog
@@ -659,6 +884,7 @@ func (fd *Feeder) PushLog(log tp.Log) {
 		}
 
 		pbLog.Result = log.Result
+		pbLog.Fileless = log.Fileless
 
 		LogQueue <- pbLog
 	}
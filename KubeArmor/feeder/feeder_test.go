@@ -4,7 +4,13 @@
 package feeder
 
 import (
+	"os/exec"
+	"sync"
 	"testing"
+	"time"
+
+	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
 )
 
 func TestFeeder(t *testing.T) {
@@ -25,3 +31,87 @@ func TestFeeder(t *testing.T) {
 
 	t.Log("[PASS] Destroyed Feeder")
 }
+
+func TestRespondToLog(t *testing.T) {
+	fd := &Feeder{
+		HostName:            "host",
+		ResponseHistory:     map[string][]time.Time{},
+		ResponseHistoryLock: &sync.Mutex{},
+	}
+
+	// a log generated before the process started (as if its PID was reused)
+	before := time.Now().UTC().Add(-time.Second).Format(kl.TimeFormUTC)
+
+	cmd := exec.Command("sleep", "10")
+	if err := cmd.Start(); err != nil {
+		t.Errorf("[FAIL] Failed to start a process (%s)", err.Error())
+		return
+	}
+	defer func() { _ = cmd.Process.Kill(); _ = cmd.Wait() }()
+
+	log := fd.RespondToLog(tp.Log{PolicyName: "ksp-kill", HostPID: int32(cmd.Process.Pid), UpdatedTime: before, Action: "Kill"})
+	if log.Response != "Skipped (PID reused)" {
+		t.Errorf("[FAIL] Responded to a process started after the log (%s)", log.Response)
+		return
+	}
+
+	t.Log("[PASS] Skipped a process started after the log")
+
+	// a log generated after the process started
+	after := time.Now().UTC().Format(kl.TimeFormUTC)
+
+	log = fd.RespondToLog(tp.Log{PolicyName: "ksp-kill", HostPID: int32(cmd.Process.Pid), UpdatedTime: after, Action: "Kill"})
+	if log.Response != "Killed" {
+		t.Errorf("[FAIL] Failed to kill the process (%s)", log.Response)
+		return
+	}
+
+	t.Log("[PASS] Killed the process")
+
+	// the process has exited
+	_ = cmd.Wait()
+
+	log = fd.RespondToLog(tp.Log{PolicyName: "ksp-kill", HostPID: int32(cmd.Process.Pid), UpdatedTime: after, Action: "Kill"})
+	if log.Response != "Skipped (exited)" {
+		t.Errorf("[FAIL] Responded to an exited process (%s)", log.Response)
+		return
+	}
+
+	t.Log("[PASS] Skipped an exited process")
+}
+
+func TestAllowResponse(t *testing.T) {
+	fd := &Feeder{
+		ResponseHistory:     map[string][]time.Time{},
+		ResponseHistoryLock: &sync.Mutex{},
+	}
+
+	for i := 0; i < ResponseLimit; i++ {
+		if !fd.allowResponse("ksp-kill_multiubuntu_ubuntu-1") {
+			t.Error("[FAIL] Rate limited a response under the limit")
+			return
+		}
+	}
+
+	if fd.allowResponse("ksp-kill_multiubuntu_ubuntu-1") {
+		t.Error("[FAIL] Failed to rate limit responses")
+		return
+	}
+
+	t.Log("[PASS] Rate limited responses")
+
+	// the histories out of the interval are dropped
+	fd.ResponseHistory["ksp-kill_multiubuntu_ubuntu-2"] = []time.Time{time.Now().Add(-2 * ResponseInterval)}
+
+	if !fd.allowResponse("ksp-kill_multiubuntu_ubuntu-3") {
+		t.Error("[FAIL] Rate limited a new response")
+		return
+	}
+
+	if _, ok := fd.ResponseHistory["ksp-kill_multiubuntu_ubuntu-2"]; ok {
+		t.Error("[FAIL] Failed to drop an old history")
+		return
+	}
+
+	t.Log("[PASS] Dropped old histories")
+}
@@ -0,0 +1,154 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package feeder
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ================== //
+// == Glob Matcher == //
+// ================== //
+
+// getDirectoryPattern Function
+func getDirectoryPattern(dir string, recursive bool) string {
+	// the same patterns as the ones in AppArmor profiles
+	if recursive {
+		return dir + "{*,**}"
+	}
+	return dir + "*"
+}
+
+// getGlobPrefix Function
+func getGlobPrefix(pattern string) string {
+	// the literal part of a pattern before any special character
+	if idx := strings.IndexAny(pattern, "*?[{\\"); idx >= 0 {
+		return pattern[:idx]
+	}
+	return pattern
+}
+
+// getGlobRegexp Function
+func getGlobRegexp(pattern string) (*regexp.Regexp, error) {
+	// AppArmor globbing
+	// *      -> any characters except '/' (at least one character right after '/')
+	// **     -> any characters including '/' (at least one character other than '/' right after '/')
+	// ?      -> any character except '/'
+	// [abc]  -> one of the characters (or ranges like a-z)
+	// [^abc] -> any character except the given ones
+	// {a,b}  -> one of the alternatives (can be nested)
+	// \x     -> x itself
+
+	expr := strings.Builder{}
+	expr.WriteString("^")
+
+	// the depth of nested alternations
+	depth := 0
+
+	// whether each alternation follows '/' (so do its alternatives)
+	alternations := []bool{}
+
+	// whether the current character follows '/'
+	afterSlash := false
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+
+		// the character after this one follows '/' only if this one is '/' (or an alternative starts after '/')
+		nextAfterSlash := c == '/'
+
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				for i+1 < len(pattern) && pattern[i+1] == '*' {
+					i++
+				}
+
+				// /dir/** does not match /dir/ itself (nor an empty path component)
+				if afterSlash {
+					expr.WriteString("[^/].*")
+				} else {
+					expr.WriteString(".*")
+				}
+			} else {
+				// /dir/* does not match /dir/ itself
+				if afterSlash {
+					expr.WriteString("[^/]+")
+				} else {
+					expr.WriteString("[^/]*")
+				}
+			}
+		case '?':
+			expr.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated character class in %s", pattern)
+			}
+
+			class := pattern[i+1 : i+1+end]
+			i = i + 1 + end
+
+			expr.WriteString("[")
+
+			if strings.HasPrefix(class, "^") {
+				expr.WriteString("^")
+				class = class[1:]
+			}
+
+			for j := 0; j < len(class); j++ {
+				if class[j] == '-' {
+					expr.WriteString("-")
+				} else if class[j] == '\\' && j+1 < len(class) {
+					j++
+					expr.WriteString(regexp.QuoteMeta(class[j : j+1]))
+				} else {
+					expr.WriteString(regexp.QuoteMeta(class[j : j+1]))
+				}
+			}
+
+			expr.WriteString("]")
+		case '{':
+			depth++
+			alternations = append(alternations, afterSlash)
+			nextAfterSlash = afterSlash
+			expr.WriteString("(?:")
+		case ',':
+			if depth > 0 {
+				nextAfterSlash = alternations[depth-1]
+				expr.WriteString("|")
+			} else {
+				expr.WriteString(",")
+			}
+		case '}':
+			if depth > 0 {
+				depth--
+				alternations = alternations[:depth]
+				expr.WriteString(")")
+			} else {
+				expr.WriteString(regexp.QuoteMeta("}"))
+			}
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				nextAfterSlash = pattern[i] == '/'
+			}
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+
+		afterSlash = nextAfterSlash
+	}
+
+	if depth > 0 {
+		return nil, fmt.Errorf("unterminated alternation in %s", pattern)
+	}
+
+	expr.WriteString("$")
+
+	return regexp.Compile(expr.String())
+}
@@ -0,0 +1,69 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package feeder
+
+import (
+	"testing"
+)
+
+func TestGlobMatcher(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		matched bool
+	}{
+		{"/etc/passwd", "/etc/passwd", true},
+		{"/etc/pass", "/etc/passwd", false},
+		{"/etc/*", "/etc/passwd", true},
+		{"/etc/*", "/etc/ssh/sshd_config", false},
+		{"/etc/**", "/etc/ssh/sshd_config", true},
+		{"/etc/{*,**}", "/etc/ssh/sshd_config", true},
+		{"/bin/*", "/bin/", false},
+		{"/bin/**", "/bin/", false},
+		{"/bin/**", "/bin//x", false},
+		{"/bin/{*,**}", "/bin/", false},
+		{"/bin/{*,**}", "/bin/ls", true},
+		{"/bin/{*,**}", "/bin/x/", true},
+		{"/tmp/a*", "/tmp/a", true},
+		{"/tmp/\\/*", "/tmp//", false},
+		{"/etc/{passwd,shadow}", "/etc/shadow", true},
+		{"/etc/{passwd,shadow}", "/etc/group", false},
+		{"/usr/{bin/{python,perl},sbin/*}", "/usr/bin/perl", true},
+		{"/usr/{bin/{python,perl},sbin/*}", "/usr/sbin/nginx", true},
+		{"/usr/{bin/{python,perl},sbin/*}", "/usr/bin/bash", false},
+		{"/tmp/?", "/tmp/a", true},
+		{"/tmp/?", "/tmp//", false},
+		{"/dev/tty[0-9]", "/dev/tty1", true},
+		{"/dev/tty[^0-9]", "/dev/tty1", false},
+		{"/dev/tty[^0-9]", "/dev/ttyS", true},
+		{"/tmp/a.b", "/tmp/axb", false},
+		{"/tmp/\\*", "/tmp/*", true},
+		{"/tmp/\\*", "/tmp/a", false},
+		{"/a,b", "/a,b", true},
+	}
+
+	for _, c := range cases {
+		regexpComp, err := getGlobRegexp(c.pattern)
+		if err != nil {
+			t.Errorf("[FAIL] Failed to compile %s (%s)", c.pattern, err.Error())
+			return
+		}
+
+		if regexpComp.MatchString(c.path) != c.matched {
+			t.Errorf("[FAIL] Unexpected match of %s with %s (expected: %v)", c.path, c.pattern, c.matched)
+			return
+		}
+	}
+
+	t.Log("[PASS] Matched paths with AppArmor globbing")
+
+	for _, pattern := range []string{"/etc/{passwd", "/dev/tty[0-9"} {
+		if _, err := getGlobRegexp(pattern); err == nil {
+			t.Errorf("[FAIL] Compiled an invalid pattern (%s)", pattern)
+			return
+		}
+	}
+
+	t.Log("[PASS] Rejected invalid patterns")
+}
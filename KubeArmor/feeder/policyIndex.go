@@ -0,0 +1,394 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package feeder
+
+import (
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+
+	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+// ================== //
+// == Policy Index == //
+// ================== //
+
+// addMatchPath Function
+func addMatchPath(root *tp.MatchPathNode, path string, idx int) {
+	node := root
+
+	for i := 0; i < len(path); i++ {
+		if node.Children == nil {
+			node.Children = map[byte]*tp.MatchPathNode{}
+		}
+
+		child, ok := node.Children[path[i]]
+		if !ok {
+			child = &tp.MatchPathNode{}
+			node.Children[path[i]] = child
+		}
+		node = child
+	}
+
+	node.Policies = append(node.Policies, idx)
+}
+
+// getMatchPaths Function
+func getMatchPaths(root *tp.MatchPathNode, resource string, candidates []int) []int {
+	if root == nil {
+		return candidates
+	}
+
+	// the paths and the directories being the prefixes of the resource
+	node := root
+	for i := 0; i < len(resource); i++ {
+		if node = node.Children[resource[i]]; node == nil {
+			break
+		}
+		candidates = append(candidates, node.Policies...)
+	}
+
+	return candidates
+}
+
+// newMatchPolicyIndex Function
+func newMatchPolicyIndex(secPolicies []tp.MatchPolicy) tp.MatchPolicyIndex {
+	index := tp.MatchPolicyIndex{
+		Scans:         map[string][]int{},
+		Paths:         map[string]*tp.MatchPathNode{},
+		Resources:     map[string]map[string][]int{},
+		Allows:        map[string][]int{},
+		Natives:       []int{},
+		Sources:       []string{},
+		SourceRegexps: []*regexp.Regexp{},
+		SourceIDs:     make([]int, len(secPolicies)),
+		GlobPrefixes:  make([]string, len(secPolicies)),
+	}
+
+	sourceIDs := map[string]int{}
+
+	for idx, secPolicy := range secPolicies {
+		// sources
+
+		index.SourceIDs[idx] = -1
+
+		if secPolicy.Source != "" {
+			if _, ok := sourceIDs[secPolicy.Source]; !ok {
+				sourceIDs[secPolicy.Source] = len(index.Sources)
+				index.Sources = append(index.Sources, secPolicy.Source)
+
+				// the sources not compiled are not matched at all
+				regexpComp, _ := getGlobRegexp(secPolicy.Source)
+				index.SourceRegexps = append(index.SourceRegexps, regexpComp)
+			}
+			index.SourceIDs[idx] = sourceIDs[secPolicy.Source]
+		}
+
+		// allow and native policies
+
+		if secPolicy.Action == "Allow" {
+			index.Allows[secPolicy.Operation] = append(index.Allows[secPolicy.Operation], idx)
+		}
+
+		if secPolicy.Native {
+			index.Natives = append(index.Natives, idx)
+		}
+
+		// resources
+
+		switch secPolicy.Operation {
+		case "Process", "File":
+			if secPolicy.ResourceType == "Path" || secPolicy.ResourceType == "Directory" {
+				if _, ok := index.Paths[secPolicy.Operation]; !ok {
+					index.Paths[secPolicy.Operation] = &tp.MatchPathNode{}
+				}
+				addMatchPath(index.Paths[secPolicy.Operation], secPolicy.Resource, idx)
+				continue
+			}
+
+			if secPolicy.ResourceType == "Glob" {
+				index.GlobPrefixes[idx] = getGlobPrefix(secPolicy.Resource)
+			}
+
+			index.Scans[secPolicy.Operation] = append(index.Scans[secPolicy.Operation], idx)
+		case "Network":
+			index.Scans[secPolicy.Operation] = append(index.Scans[secPolicy.Operation], idx)
+		case "Kernel", "Privilege":
+			if _, ok := index.Resources[secPolicy.Operation]; !ok {
+				index.Resources[secPolicy.Operation] = map[string][]int{}
+			}
+			index.Resources[secPolicy.Operation][secPolicy.Resource] = append(index.Resources[secPolicy.Operation][secPolicy.Resource], idx)
+		}
+	}
+
+	return index
+}
+
+// ================== //
+// == Policy Event == //
+// ================== //
+
+// matchEvent Structure
+type matchEvent struct {
+	// the first fields of the source and the resource
+	Source   string
+	Resource string
+
+	// the exec path (process) or the whole resource (file) to match
+	Target string
+
+	// runc:[2:INIT] is matched by the resource instead
+	RuncInit bool
+
+	// network
+	IP     net.IP
+	Port   int
+	Domain string
+
+	// source ID -> 0 (unknown), 1 (matched), -1 (not matched)
+	SourceMatches []int8
+}
+
+// newMatchEvent Function
+func newMatchEvent(index tp.MatchPolicyIndex, log tp.Log) *matchEvent {
+	ev := &matchEvent{
+		Source:        strings.Split(log.Source, " ")[0],
+		Resource:      strings.Split(log.Resource, " ")[0],
+		RuncInit:      log.Source == "runc:[2:INIT]",
+		SourceMatches: make([]int8, len(index.Sources)),
+	}
+
+	if log.Operation == "Process" {
+		ev.Target = ev.Resource
+	} else if log.Operation == "File" {
+		ev.Target = log.Resource
+	} else if log.Operation == "Network" {
+		ev.IP, ev.Port = getIPAndPortFromResource(log.Resource)
+		ev.Domain = getDomainFromResource(log.Resource)
+	}
+
+	return ev
+}
+
+// matchSource Function
+func (ev *matchEvent) matchSource(index tp.MatchPolicyIndex, idx int, runcInit bool) bool {
+	sourceID := index.SourceIDs[idx]
+	if sourceID < 0 {
+		return true
+	}
+
+	sourceRegexp := index.SourceRegexps[sourceID]
+	if sourceRegexp == nil {
+		return false
+	}
+
+	if ev.SourceMatches[sourceID] == 0 {
+		if sourceRegexp.MatchString(ev.Source) {
+			ev.SourceMatches[sourceID] = 1
+		} else {
+			ev.SourceMatches[sourceID] = -1
+		}
+	}
+
+	if ev.SourceMatches[sourceID] > 0 {
+		return true
+	}
+
+	// runc:[2:INIT] is matched by the exec path of the container process instead
+	return runcInit && ev.RuncInit && sourceRegexp.MatchString(ev.Resource)
+}
+
+// matchTarget Function
+func matchTarget(secPolicy tp.MatchPolicy, log tp.Log) bool {
+	// rules with fromUser are only applied to the given users
+	if !matchUser(secPolicy.FromUser, log.UID) {
+		return false
+	}
+
+	// rules with containers are only applied to the given containers
+	if len(secPolicy.Containers) > 0 && !kl.ContainsElement(secPolicy.Containers, log.ContainerName) {
+		return false
+	}
+
+	return true
+}
+
+// matchPolicy Function
+func matchPolicy(index tp.MatchPolicyIndex, secPolicy tp.MatchPolicy, idx int, log tp.Log, ev *matchEvent) bool {
+	if secPolicy.Operation != log.Operation || !matchTarget(secPolicy, log) {
+		return false
+	}
+
+	switch log.Operation {
+	case "Process", "File":
+		matched := false
+
+		switch secPolicy.ResourceType {
+		case "Path":
+			matched = ev.Target == secPolicy.Resource
+		case "Directory":
+			matched = matchDirectory(secPolicy, ev.Target)
+		case "Glob":
+			// skip the patterns whose literal prefixes are not matched
+			if secPolicy.Regexp != nil && strings.HasPrefix(ev.Target, index.GlobPrefixes[idx]) {
+				matched = secPolicy.Regexp.MatchString(ev.Target)
+			}
+		case "Regexp":
+			if secPolicy.Regexp != nil {
+				matched = secPolicy.Regexp.MatchString(ev.Target)
+			}
+		case "Fileless":
+			// fileless executions are matched by their types, not by their paths
+			matched = log.Fileless && strings.Contains(log.Data, secPolicy.Resource)
+		}
+
+		// rules with arguments are matched by the exec path and the arguments
+		if matched && len(secPolicy.Args) > 0 {
+			matched = matchArgs(secPolicy, log.Args)
+		}
+
+		return matched && ev.matchSource(index, idx, true)
+	case "Network":
+		matched := false
+
+		switch secPolicy.ResourceType {
+		case "IP":
+			if ev.IP != nil && secPolicy.IPNet != nil && secPolicy.IPNet.Contains(ev.IP) {
+				matched = len(secPolicy.Ports) == 0 || kl.ContainsElement(secPolicy.Ports, ev.Port)
+			}
+		case "Port":
+			matched = kl.ContainsElement(secPolicy.Ports, ev.Port)
+		case "Domain":
+			matched = matchDomain(secPolicy.Resource, ev.Domain)
+		default:
+			matched = strings.Contains(log.Resource, secPolicy.Resource)
+		}
+
+		return matched && ev.matchSource(index, idx, false)
+	case "Kernel", "Privilege":
+		if ev.Resource != secPolicy.Resource {
+			return false
+		}
+
+		// rules with hostNamespace are only applied to the processes joining the namespaces of the host
+		if secPolicy.HostNamespace && !kl.ContainsElement(strings.Split(log.Resource, " "), "namespace=host") {
+			return false
+		}
+
+		return ev.matchSource(index, idx, false)
+	}
+
+	return false
+}
+
+// getMatchPolicyCandidates Function
+func getMatchPolicyCandidates(index tp.MatchPolicyIndex, log tp.Log, ev *matchEvent) []int {
+	candidates := []int{}
+
+	switch log.Operation {
+	case "Process", "File":
+		candidates = append(candidates, index.Scans[log.Operation]...)
+		candidates = getMatchPaths(index.Paths[log.Operation], ev.Target, candidates)
+	case "Network":
+		candidates = append(candidates, index.Scans[log.Operation]...)
+	case "Kernel", "Privilege":
+		candidates = append(candidates, index.Resources[log.Operation][ev.Resource]...)
+	}
+
+	// the later policies take precedence over the earlier ones
+	sort.Sort(sort.Reverse(sort.IntSlice(candidates)))
+
+	uniqueCandidates := candidates[:0]
+	for i, idx := range candidates {
+		if i == 0 || idx != candidates[i-1] {
+			uniqueCandidates = append(uniqueCandidates, idx)
+		}
+	}
+
+	return uniqueCandidates
+}
+
+// matchPolicies Function
+func matchPolicies(matches tp.MatchPolicies, log tp.Log) (tp.Log, matchResult) {
+	result := matchResult{}
+
+	index := matches.Index
+	ev := newMatchEvent(index, log)
+
+	matched := false
+	taggedPolicy := false
+	messagedPolicy := false
+
+	// the last matched policy decides the action, while the tags and the message come from the last ones having them
+	for _, idx := range getMatchPolicyCandidates(index, log, ev) {
+		secPolicy := matches.Policies[idx]
+
+		if !matchPolicy(index, secPolicy, idx, log, ev) {
+			continue
+		}
+
+		if !matched {
+			log.PolicyName = secPolicy.PolicyName
+			log.Severity = secPolicy.Severity
+
+			log.Type = "MatchedPolicy"
+			log.Action = secPolicy.Action
+			log.QuarantineActions = getQuarantineActions(secPolicy)
+
+			matched = true
+		}
+
+		if !taggedPolicy && len(secPolicy.Tags) > 0 {
+			log.Tags = strings.Join(secPolicy.Tags[:], ",")
+			taggedPolicy = true
+		}
+
+		if !messagedPolicy && len(secPolicy.Message) > 0 {
+			log.Message = secPolicy.Message
+			messagedPolicy = true
+		}
+
+		if taggedPolicy && messagedPolicy {
+			break
+		}
+	}
+
+	// allow and native policies are only needed when no policy is matched
+	if log.Type != "" {
+		return log, result
+	}
+
+	for _, idx := range index.Allows[log.Operation] {
+		secPolicy := matches.Policies[idx]
+
+		if matchTarget(secPolicy, log) && ev.matchSource(index, idx, true) {
+			result.addAllowPolicy(secPolicy)
+		}
+
+		// the allow rules with arguments or users are enforced only by their resources
+		if len(secPolicy.Args) > 0 || hasFromUser(secPolicy.FromUser) {
+			narrowed := secPolicy
+			narrowed.Args = nil
+			narrowed.FromUser = tp.MatchUserType{}
+
+			if matchPolicy(index, narrowed, idx, log, ev) {
+				result.AllowNarrowed.addPolicy(secPolicy)
+			}
+		}
+	}
+
+	if log.Result != "Passed" {
+		for _, idx := range index.Natives {
+			if matchTarget(matches.Policies[idx], log) {
+				result.MightBeNative = true
+				break
+			}
+		}
+	}
+
+	return log, result
+}
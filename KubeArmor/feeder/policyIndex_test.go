@@ -0,0 +1,444 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package feeder
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+// ======================= //
+// == Reference Matcher == //
+// ======================= //
+
+// globMatchReference Function
+func globMatchReference(pattern, name string) bool {
+	// glob patterns are checked in globMatcher_test.go
+	re, err := getGlobRegexp(pattern)
+	return err == nil && re.MatchString(name)
+}
+
+// matchTargetReference Function
+func matchTargetReference(secPolicy tp.MatchPolicy, log tp.Log) bool {
+	for _, uid := range secPolicy.FromUser.ExcludeUIDs {
+		if int32(uid) == log.UID {
+			return false
+		}
+	}
+
+	if len(secPolicy.FromUser.MatchUIDs) > 0 {
+		matched := false
+		for _, uid := range secPolicy.FromUser.MatchUIDs {
+			matched = matched || int32(uid) == log.UID
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(secPolicy.Containers) > 0 {
+		matched := false
+		for _, container := range secPolicy.Containers {
+			matched = matched || container == log.ContainerName
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchSourceReference Function
+func matchSourceReference(secPolicy tp.MatchPolicy, log tp.Log, runcInit bool) bool {
+	if secPolicy.Source == "" {
+		return true
+	}
+
+	if globMatchReference(secPolicy.Source, strings.Split(log.Source, " ")[0]) {
+		return true
+	}
+
+	// runc:[2:INIT] is matched by the exec path of the container process instead
+	return runcInit && log.Source == "runc:[2:INIT]" && globMatchReference(secPolicy.Source, strings.Split(log.Resource, " ")[0])
+}
+
+// matchArgsReference Function
+func matchArgsReference(secPolicy tp.MatchPolicy, args []string) bool {
+	for _, rule := range secPolicy.Args {
+		matched := false
+
+		for pos, arg := range args {
+			if rule.Index > 0 && rule.Index != pos+1 {
+				continue
+			}
+
+			switch rule.Type {
+			case "Glob":
+				matched = matched || globMatchReference(rule.Arg, arg)
+			case "Regexp":
+				matched = matched || regexp.MustCompile(rule.Arg).MatchString(arg)
+			default:
+				matched = matched || rule.Arg == arg
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchPolicyReference Function
+func matchPolicyReference(secPolicy tp.MatchPolicy, log tp.Log) bool {
+	if secPolicy.Operation != log.Operation || !matchTargetReference(secPolicy, log) {
+		return false
+	}
+
+	tokens := strings.Fields(log.Resource)
+
+	switch log.Operation {
+	case "Process", "File":
+		target := log.Resource
+		if log.Operation == "Process" {
+			target = tokens[0]
+		}
+
+		matched := false
+
+		switch secPolicy.ResourceType {
+		case "Path":
+			matched = target == secPolicy.Resource
+		case "Directory":
+			rest := strings.TrimPrefix(target, secPolicy.Resource)
+			matched = rest != target && rest != "" && (secPolicy.Recursive || !strings.Contains(rest, "/"))
+		case "Glob":
+			matched = globMatchReference(secPolicy.Resource, target)
+		case "Regexp":
+			matched = regexp.MustCompile(secPolicy.Resource).MatchString(target)
+		case "Fileless":
+			matched = log.Fileless && strings.Contains(log.Data, secPolicy.Resource)
+		}
+
+		return matched && matchArgsReference(secPolicy, log.Args) && matchSourceReference(secPolicy, log, true)
+	case "Network":
+		var ip net.IP
+		port := -1
+		domain := ""
+
+		for _, token := range tokens {
+			if strings.HasPrefix(token, "sin_addr=") {
+				ip = net.ParseIP(token[len("sin_addr="):])
+			} else if strings.HasPrefix(token, "sin_port=") {
+				port, _ = strconv.Atoi(token[len("sin_port="):])
+			} else if strings.HasPrefix(token, "domain=") {
+				domain = strings.TrimSuffix(strings.ToLower(token[len("domain="):]), ".")
+			}
+		}
+
+		hasPort := false
+		for _, p := range secPolicy.Ports {
+			hasPort = hasPort || p == port
+		}
+
+		matched := false
+
+		switch secPolicy.ResourceType {
+		case "IP":
+			matched = ip != nil && secPolicy.IPNet != nil && secPolicy.IPNet.Contains(ip) && (len(secPolicy.Ports) == 0 || hasPort)
+		case "Port":
+			matched = hasPort
+		case "Domain":
+			if strings.HasPrefix(secPolicy.Resource, "*.") {
+				matched = domain != "" && strings.HasSuffix(domain, secPolicy.Resource[1:])
+			} else {
+				matched = domain != "" && domain == secPolicy.Resource
+			}
+		default:
+			matched = strings.Contains(log.Resource, secPolicy.Resource)
+		}
+
+		return matched && matchSourceReference(secPolicy, log, false)
+	case "Kernel", "Privilege":
+		if tokens[0] != secPolicy.Resource {
+			return false
+		}
+
+		if secPolicy.HostNamespace {
+			hostNamespace := false
+			for _, token := range tokens {
+				hostNamespace = hostNamespace || token == "namespace=host"
+			}
+			if !hostNamespace {
+				return false
+			}
+		}
+
+		return matchSourceReference(secPolicy, log, false)
+	}
+
+	return false
+}
+
+// matchPoliciesReference Function (checks all the policies one by one without the index)
+func matchPoliciesReference(secPolicies []tp.MatchPolicy, log tp.Log) (tp.Log, matchResult) {
+	result := matchResult{}
+
+	// the later policies take precedence over the earlier ones
+	for idx := len(secPolicies) - 1; idx >= 0; idx-- {
+		secPolicy := secPolicies[idx]
+
+		if !matchPolicyReference(secPolicy, log) {
+			continue
+		}
+
+		if log.Type == "" {
+			log.PolicyName = secPolicy.PolicyName
+			log.Severity = secPolicy.Severity
+			log.Type = "MatchedPolicy"
+			log.Action = secPolicy.Action
+			log.QuarantineActions = getQuarantineActions(secPolicy)
+		}
+
+		if log.Tags == "" && len(secPolicy.Tags) > 0 {
+			log.Tags = strings.Join(secPolicy.Tags, ",")
+		}
+
+		if log.Message == "" && len(secPolicy.Message) > 0 {
+			log.Message = secPolicy.Message
+		}
+	}
+
+	if log.Type != "" {
+		return log, result
+	}
+
+	for _, secPolicy := range secPolicies {
+		if secPolicy.Action != "Allow" || secPolicy.Operation != log.Operation {
+			continue
+		}
+
+		if matchTargetReference(secPolicy, log) && matchSourceReference(secPolicy, log, true) {
+			result.addAllowPolicy(secPolicy)
+		}
+
+		if len(secPolicy.Args) > 0 || len(secPolicy.FromUser.MatchUIDs) > 0 || len(secPolicy.FromUser.ExcludeUIDs) > 0 {
+			narrowed := secPolicy
+			narrowed.Args = nil
+			narrowed.FromUser = tp.MatchUserType{}
+
+			if matchPolicyReference(narrowed, log) {
+				result.AllowNarrowed.addPolicy(secPolicy)
+			}
+		}
+	}
+
+	for _, secPolicy := range secPolicies {
+		if secPolicy.Native && log.Result != "Passed" && matchTargetReference(secPolicy, log) {
+			result.MightBeNative = true
+		}
+	}
+
+	return log, result
+}
+
+// ===================== //
+// == Test Generators == //
+// ===================== //
+
+var testSources = []string{"/bin/bash", "/usr/bin/python3", "/bin/*", "/usr/{*,**}"}
+var testProcesses = []string{"/bin/sleep", "/bin/ls", "/usr/bin/curl", "/usr/bin/python3", "/usr/local/bin/app", "/bin/bash"}
+var testFiles = []string{"/etc/passwd", "/etc/shadow", "/etc/hosts", "/proc/1/status", "/var/log/app.log", "/tmp/secret.txt", "/root/.ssh/id_rsa", "/var/log/nginx/access.log"}
+var testDirectories = []string{"/bin/", "/usr/bin/", "/etc/", "/proc/", "/var/log/", "/tmp/", "/root/.ssh/"}
+var testPatterns = []string{"/etc/*", "/proc/*/status", "/tmp/*.txt", "/var/log/[a-z]*.log", "/usr/bin/py*", "/root/[^a]*", "/**/id_rsa", "/etc/{passwd,shadow}"}
+var testDomains = []string{"example.com", "*.example.com", "kubearmor.io"}
+var testActions = []string{"Allow", "Audit", "Block", "Audit (Block)"}
+
+// getTestPolicies Function
+func getTestPolicies(r *rand.Rand, count int) tp.MatchPolicies {
+	matches := tp.MatchPolicies{}
+
+	pick := func(values []string) string {
+		return values[r.Intn(len(values))]
+	}
+
+	for i := 0; i < count; i++ {
+		match := tp.MatchPolicy{
+			PolicyName: "ksp-" + strconv.Itoa(r.Intn(count/4+1)),
+			Severity:   strconv.Itoa(r.Intn(10) + 1),
+			Action:     pick(testActions),
+		}
+
+		if r.Intn(3) == 0 {
+			match.Tags = []string{"tag-" + strconv.Itoa(r.Intn(5))}
+		}
+
+		if r.Intn(3) == 0 {
+			match.Message = "message-" + strconv.Itoa(r.Intn(5))
+		}
+
+		if r.Intn(4) == 0 {
+			match.Source = pick(testSources)
+		}
+
+		if r.Intn(8) == 0 {
+			match.Containers = []string{"container-" + strconv.Itoa(r.Intn(2))}
+		}
+
+		if r.Intn(10) == 0 {
+			match.FromUser = tp.MatchUserType{MatchUIDs: []int{r.Intn(2)}}
+		}
+
+		switch r.Intn(12) {
+		case 0, 1:
+			match.Operation, match.ResourceType, match.Resource = "Process", "Path", pick(testProcesses)
+			if r.Intn(4) == 0 {
+				arg := tp.MatchArgType{Arg: "-" + strconv.Itoa(r.Intn(3)), Index: r.Intn(3)}
+				if r.Intn(3) == 0 {
+					arg.Arg, arg.Type = "-[0-1]", "Glob"
+				}
+				match.Args = []tp.MatchArgType{arg}
+				match.ArgRegexps = make([]*regexp.Regexp, 1)
+				if arg.Type == "Glob" {
+					match.ArgRegexps[0], _ = getGlobRegexp(arg.Arg)
+				}
+			}
+		case 2:
+			match.Operation, match.ResourceType, match.Resource = "Process", "Directory", pick(testDirectories)
+			match.Recursive = r.Intn(2) == 0
+		case 3:
+			match.Operation, match.ResourceType, match.Resource = "Process", "Fileless", "fileless=memfd"
+		case 4, 5:
+			match.Operation, match.ResourceType, match.Resource = "File", "Path", pick(testFiles)
+		case 6:
+			match.Operation, match.ResourceType, match.Resource = "File", "Directory", pick(testDirectories)
+			match.Recursive = r.Intn(2) == 0
+		case 7:
+			match.Operation, match.ResourceType, match.Resource = "File", "Glob", pick(testPatterns)
+			match.Regexp, _ = getGlobRegexp(match.Resource)
+		case 8:
+			match.Operation, match.ResourceType, match.Resource = "Network", "Protocol", pick([]string{"domain=AF_INET", "type=SOCK_STREAM", "protocol=17"})
+		case 9:
+			match.Operation, match.ResourceType = "Network", pick([]string{"IP", "Port", "Domain"})
+			match.Resource = pick(testDomains)
+			match.IPNet = getIPNetFromName(pick([]string{"10.0.0.0/8", "192.168.1.1"}))
+			match.Ports = []int{[]int{53, 80}[r.Intn(2)]}
+		case 10:
+			match.Operation, match.ResourceType, match.Resource = "Kernel", "Kernel", pick([]string{"operation=ModuleLoad", "operation=BPFProgLoad"})
+		default:
+			if r.Intn(2) == 0 {
+				match.Operation, match.ResourceType, match.Resource = "Privilege", "Syscall", pick([]string{"syscall=setuid", "syscall=unshare", "syscall=setns"})
+				match.HostNamespace = match.Resource == "syscall=setns" && r.Intn(2) == 0
+			} else {
+				match = tp.MatchPolicy{PolicyName: match.PolicyName, Native: true, Containers: match.Containers}
+			}
+		}
+
+		matches.Policies = append(matches.Policies, match)
+	}
+
+	matches.Index = newMatchPolicyIndex(matches.Policies)
+
+	return matches
+}
+
+// getTestLogs Function
+func getTestLogs(r *rand.Rand, count int) []tp.Log {
+	logs := []tp.Log{}
+
+	pick := func(values []string) string {
+		return values[r.Intn(len(values))]
+	}
+
+	for i := 0; i < count; i++ {
+		log := tp.Log{
+			ContainerID:   "container-id",
+			ContainerName: "container-" + strconv.Itoa(r.Intn(2)),
+			UID:           int32(r.Intn(2)),
+			Source:        pick(testProcesses) + " -c",
+			Result:        pick([]string{"Passed", "Passed", "Permission denied"}),
+		}
+
+		if r.Intn(10) == 0 {
+			log.Source = "runc:[2:INIT]"
+		}
+
+		switch r.Intn(5) {
+		case 0:
+			log.Operation = "Process"
+			log.Args = []string{"-" + strconv.Itoa(r.Intn(3)), pick([]string{"-0", "-2", "script.py"})}[:r.Intn(3)]
+			log.Resource = strings.TrimSpace(pick(testProcesses) + " " + strings.Join(log.Args, " "))
+			if r.Intn(8) == 0 {
+				log.Fileless = true
+				log.Data = "fileless=memfd"
+			}
+		case 1, 2:
+			log.Operation = "File"
+			log.Resource = pick(append(append([]string{}, testFiles...), "/etc/passwd-", "/proc/self/root/etc/shadow", "/etc/", "/var/log/"))
+		case 3:
+			log.Operation = "Network"
+			log.Resource = pick([]string{
+				"domain=AF_INET type=SOCK_STREAM protocol=0",
+				"sa_family=AF_INET sin_port=53 sin_addr=10.1.2.3",
+				"sa_family=AF_INET sin_port=80 sin_addr=192.168.1.1",
+				"domain=www.example.com",
+				"domain=example.com.",
+			})
+		default:
+			log.Operation = pick([]string{"Kernel", "Privilege"})
+			log.Resource = pick([]string{"operation=ModuleLoad name=dummy", "syscall=setuid uid=0", "syscall=unshare flags=0", "syscall=setns fd=3 nstype=0", "syscall=setns fd=3 nstype=0 namespace=host"})
+		}
+
+		logs = append(logs, log)
+	}
+
+	return logs
+}
+
+func TestMatchPolicyIndex(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for round := 0; round < 50; round++ {
+		matches := getTestPolicies(r, 10+round*4)
+
+		for _, log := range getTestLogs(r, 200) {
+			referenceLog := getMatchedLog(matchPoliciesReference(matches.Policies, log))
+			indexedLog := getMatchedLog(matchPolicies(matches, log))
+
+			if !reflect.DeepEqual(referenceLog, indexedLog) {
+				t.Errorf("[FAIL] Different matches for %s %s (reference: %v, indexed: %v)", log.Operation, log.Resource, referenceLog, indexedLog)
+				return
+			}
+		}
+	}
+
+	t.Log("[PASS] Matched the same policies as the reference matcher with the index")
+}
+
+func BenchmarkMatchPolicies(b *testing.B) {
+	for _, count := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("%d", count), func(b *testing.B) {
+			r := rand.New(rand.NewSource(1))
+
+			matches := getTestPolicies(r, count)
+			logs := getTestLogs(r, 1024)
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				getMatchedLog(matchPolicies(matches, logs[i%len(logs)]))
+			}
+		})
+	}
+}
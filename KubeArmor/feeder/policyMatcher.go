@@ -4,7 +4,7 @@
 package feeder
 
 import (
-	"path/filepath"
+	"net"
 	"regexp"
 	"strconv"
 	"strings"
@@ -31,6 +31,69 @@ func getProtocolFromName(proto string) string {
 	}
 }
 
+// getIPNetFromName Function
+func getIPNetFromName(ip string) *net.IPNet {
+	if !strings.Contains(ip, "/") {
+		if addr := net.ParseIP(ip); addr != nil && addr.To4() == nil {
+			ip = ip + "/128"
+		} else {
+			ip = ip + "/32"
+		}
+	}
+
+	_, ipNet, err := net.ParseCIDR(ip)
+	if err != nil {
+		return nil
+	}
+
+	return ipNet
+}
+
+// getIPAndPortFromResource Function
+func getIPAndPortFromResource(resource string) (net.IP, int) {
+	var ip net.IP
+	port := -1
+
+	for _, token := range strings.Split(resource, " ") {
+		if strings.HasPrefix(token, "sin_addr=") {
+			ip = net.ParseIP(strings.TrimPrefix(token, "sin_addr="))
+		} else if strings.HasPrefix(token, "sin_port=") {
+			if val, err := strconv.Atoi(strings.TrimPrefix(token, "sin_port=")); err == nil {
+				port = val
+			}
+		}
+	}
+
+	return ip, port
+}
+
+// getDomainFromResource Function
+func getDomainFromResource(resource string) string {
+	for _, token := range strings.Split(resource, " ") {
+		if strings.HasPrefix(token, "domain=") {
+			return strings.TrimPrefix(token, "domain=")
+		}
+	}
+
+	return ""
+}
+
+// matchDomain Function
+func matchDomain(pattern, domain string) bool {
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+
+	if domain == "" {
+		return false
+	}
+
+	// *.example.com matches the subdomains of example.com
+	if strings.HasPrefix(pattern, "*.") {
+		return strings.HasSuffix(domain, pattern[1:])
+	}
+
+	return domain == pattern
+}
+
 // getOperationAndCapabilityFromName
 func getOperationAndCapabilityFromName(capName string) (op, cap string) {
 	switch strings.ToLower(capName) {
@@ -44,6 +107,143 @@ func getOperationAndCapabilityFromName(capName string) (op, cap string) {
 	return op, cap
 }
 
+// setMatchArgs Function
+func (fd *Feeder) setMatchArgs(match *tp.MatchPolicy, matchArgs []tp.MatchArgType) bool {
+	match.Args = matchArgs
+	match.ArgRegexps = make([]*regexp.Regexp, len(matchArgs))
+
+	for idx, arg := range matchArgs {
+		if arg.Type == "Glob" {
+			// the same globbing as the one for paths
+			regexpComp, err := getGlobRegexp(arg.Arg)
+			if err != nil {
+				fd.Debugf("MatchPolicy Glob compilation error: %s\n", arg.Arg)
+				return false
+			}
+			match.ArgRegexps[idx] = regexpComp
+		} else if arg.Type == "Regexp" {
+			regexpComp, err := regexp.Compile(arg.Arg)
+			if err != nil {
+				fd.Debugf("MatchPolicy Regexp compilation error: %s\n", arg.Arg)
+				return false
+			}
+			match.ArgRegexps[idx] = regexpComp
+		}
+	}
+
+	return true
+}
+
+// matchDirectory Function
+func matchDirectory(secPolicy tp.MatchPolicy, path string) bool {
+	// the same as dir/* and dir/{*,**} in AppArmor, which do not match the directory itself
+	if len(path) <= len(secPolicy.Resource) || !strings.HasPrefix(path, secPolicy.Resource) {
+		return false
+	}
+
+	// non-recursive directories only cover the files right under them
+	return secPolicy.Recursive || !strings.Contains(path[len(secPolicy.Resource):], "/")
+}
+
+// matchArgs Function
+func matchArgs(secPolicy tp.MatchPolicy, args []string) bool {
+	// each argument rule should match the argument at its index, or at least one of the arguments without the index
+	for idx, rule := range secPolicy.Args {
+		matched := false
+
+		for pos, arg := range args {
+			if rule.Index > 0 && rule.Index != pos+1 {
+				continue
+			}
+
+			switch rule.Type {
+			case "Glob", "Regexp":
+				matched = secPolicy.ArgRegexps[idx] != nil && secPolicy.ArgRegexps[idx].MatchString(arg)
+			default:
+				matched = rule.Arg == arg
+			}
+
+			if matched {
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasFromUser Function
+func hasFromUser(user tp.MatchUserType) bool {
+	return len(user.MatchUIDs) > 0 || len(user.ExcludeUIDs) > 0
+}
+
+// matchUser Function
+func matchUser(user tp.MatchUserType, uid int32) bool {
+	if len(user.MatchUIDs) > 0 && !kl.ContainsElement(user.MatchUIDs, int(uid)) {
+		return false
+	}
+
+	if len(user.ExcludeUIDs) > 0 && kl.ContainsElement(user.ExcludeUIDs, int(uid)) {
+		return false
+	}
+
+	return true
+}
+
+// getFilelessTypeFromName Function
+func getFilelessTypeFromName(typeName string) string {
+	switch strings.ToLower(typeName) {
+	case "memfd":
+		return "fileless=memfd"
+	case "deleted":
+		return "fileless=deleted"
+	default:
+		return ""
+	}
+}
+
+// getKernelOperationFromName Function
+func getKernelOperationFromName(opName string) string {
+	switch strings.ToLower(opName) {
+	case "moduleload":
+		return "operation=ModuleLoad"
+	case "moduleunload":
+		return "operation=ModuleUnload"
+	case "bpfprogload":
+		return "operation=BPFProgLoad"
+	default:
+		return ""
+	}
+}
+
+// getPrivilegeSyscallFromName Function
+func getPrivilegeSyscallFromName(syscallName string) string {
+	switch strings.ToLower(syscallName) {
+	case "setuid", "setgid", "setreuid", "setregid", "setresuid", "setresgid", "setns", "unshare", "mount", "ptrace":
+		return "syscall=" + strings.ToLower(syscallName)
+	default:
+		return ""
+	}
+}
+
+// getQuarantineActions Function
+func getQuarantineActions(secPolicy tp.MatchPolicy) []string {
+	if len(secPolicy.Response.Actions) == 0 {
+		return nil
+	}
+
+	// quarantine the pod only if the severity of the policy reaches the threshold
+	if severity, err := strconv.Atoi(secPolicy.Severity); err == nil && severity >= secPolicy.Response.MinSeverity {
+		return secPolicy.Response.Actions
+	}
+
+	return nil
+}
+
 // newMatchPolicy Function
 func (fd *Feeder) newMatchPolicy(policyEnabled int, policyName, src string, mp interface{}) tp.MatchPolicy {
 	match := tp.MatchPolicy{
@@ -55,13 +255,21 @@ func (fd *Feeder) newMatchPolicy(policyEnabled int, policyName, src string, mp i
 		match.Severity = strconv.Itoa(ppt.Severity)
 		match.Tags = ppt.Tags
 		match.Message = ppt.Message
+		match.FromUser = ppt.FromUser
 
 		match.Operation = "Process"
 		match.Resource = ppt.Path
 		match.ResourceType = "Path"
 
+		if len(ppt.MatchArgs) > 0 && !fd.setMatchArgs(&match, ppt.MatchArgs) {
+			return tp.MatchPolicy{}
+		}
+
 		if policyEnabled == tp.KubeArmorPolicyAudited && strings.HasPrefix(ppt.Action, "Block") {
 			match.Action = "Audit (" + ppt.Action + ")"
+		} else if len(ppt.MatchArgs) > 0 && strings.HasPrefix(ppt.Action, "Block") {
+			// arguments can be audited, but cannot be blocked by enforcers (enforcers only allow the paths of the allow rules)
+			match.Action = "Audit (" + ppt.Action + ")"
 		} else {
 			match.Action = ppt.Action
 		}
@@ -69,13 +277,22 @@ func (fd *Feeder) newMatchPolicy(policyEnabled int, policyName, src string, mp i
 		match.Severity = strconv.Itoa(pdt.Severity)
 		match.Tags = pdt.Tags
 		match.Message = pdt.Message
+		match.FromUser = pdt.FromUser
 
 		match.Operation = "Process"
 		match.Resource = pdt.Directory
 		match.ResourceType = "Directory"
+		match.Recursive = pdt.Recursive
+
+		if len(pdt.MatchArgs) > 0 && !fd.setMatchArgs(&match, pdt.MatchArgs) {
+			return tp.MatchPolicy{}
+		}
 
 		if policyEnabled == tp.KubeArmorPolicyAudited && strings.HasPrefix(pdt.Action, "Block") {
 			match.Action = "Audit (" + pdt.Action + ")"
+		} else if len(pdt.MatchArgs) > 0 && strings.HasPrefix(pdt.Action, "Block") {
+			// arguments can be audited, but cannot be blocked by enforcers (enforcers only allow the paths of the allow rules)
+			match.Action = "Audit (" + pdt.Action + ")"
 		} else {
 			match.Action = pdt.Action
 		}
@@ -97,6 +314,7 @@ func (fd *Feeder) newMatchPolicy(policyEnabled int, policyName, src string, mp i
 		match.Severity = strconv.Itoa(fpt.Severity)
 		match.Tags = fpt.Tags
 		match.Message = fpt.Message
+		match.FromUser = fpt.FromUser
 
 		match.Operation = "File"
 		match.Resource = fpt.Path
@@ -111,10 +329,12 @@ func (fd *Feeder) newMatchPolicy(policyEnabled int, policyName, src string, mp i
 		match.Severity = strconv.Itoa(fdt.Severity)
 		match.Tags = fdt.Tags
 		match.Message = fdt.Message
+		match.FromUser = fdt.FromUser
 
 		match.Operation = "File"
 		match.Resource = fdt.Directory
 		match.ResourceType = "Directory"
+		match.Recursive = fdt.Recursive
 
 		if policyEnabled == tp.KubeArmorPolicyAudited && strings.HasPrefix(fdt.Action, "Block") {
 			match.Action = "Audit (" + fdt.Action + ")"
@@ -138,6 +358,7 @@ func (fd *Feeder) newMatchPolicy(policyEnabled int, policyName, src string, mp i
 		match.Severity = strconv.Itoa(npt.Severity)
 		match.Tags = npt.Tags
 		match.Message = npt.Message
+		match.FromUser = npt.FromUser
 
 		match.Operation = "Network"
 		match.Resource = getProtocolFromName(npt.Protocol)
@@ -150,6 +371,65 @@ func (fd *Feeder) newMatchPolicy(policyEnabled int, policyName, src string, mp i
 		} else {
 			match.Action = npt.Action
 		}
+	} else if nit, ok := mp.(tp.NetworkIPType); ok {
+		match.Severity = strconv.Itoa(nit.Severity)
+		match.Tags = nit.Tags
+		match.Message = nit.Message
+		match.FromUser = nit.FromUser
+
+		match.Operation = "Network"
+		match.Resource = nit.IP
+		match.ResourceType = "IP"
+
+		match.IPNet = getIPNetFromName(nit.IP)
+		if match.IPNet == nil {
+			match.Resource = ""
+		}
+		match.Ports = nit.Ports
+
+		// no enforcer supports IP-based rules yet, so they are only audited
+		if strings.HasPrefix(nit.Action, "Block") {
+			match.Action = "Audit (" + nit.Action + ")"
+		} else {
+			match.Action = nit.Action
+		}
+	} else if npt, ok := mp.(tp.NetworkPortType); ok {
+		match.Severity = strconv.Itoa(npt.Severity)
+		match.Tags = npt.Tags
+		match.Message = npt.Message
+		match.FromUser = npt.FromUser
+
+		match.Operation = "Network"
+		match.Resource = ""
+		match.ResourceType = "Port"
+
+		if npt.Port > 0 && npt.Port < 65536 {
+			match.Resource = strconv.Itoa(npt.Port)
+		}
+		match.Ports = []int{npt.Port}
+
+		// no enforcer supports port-based rules yet, so they are only audited
+		if strings.HasPrefix(npt.Action, "Block") {
+			match.Action = "Audit (" + npt.Action + ")"
+		} else {
+			match.Action = npt.Action
+		}
+	} else if ndt, ok := mp.(tp.NetworkDomainType); ok {
+		match.Severity = strconv.Itoa(ndt.Severity)
+		match.Tags = ndt.Tags
+		match.Message = ndt.Message
+		match.FromUser = ndt.FromUser
+
+		match.Operation = "Network"
+		match.Resource = strings.TrimSuffix(strings.ToLower(ndt.Domain), ".")
+		match.ResourceType = "Domain"
+
+		// DNS queries are only monitored, so domain-based rules are only audited
+		if strings.HasPrefix(ndt.Action, "Block") {
+			match.Action = "Audit (" + ndt.Action + ")"
+		} else {
+			match.Action = ndt.Action
+		}
 	} else if cct, ok := mp.(tp.CapabilitiesCapabilityType); ok {
 		match.Severity = strconv.Itoa(cct.Severity)
 		match.Tags = cct.Tags
@@ -166,10 +446,77 @@ func (fd *Feeder) newMatchPolicy(policyEnabled int, policyName, src string, mp i
 		} else {
 			match.Action = cct.Action
 		}
+	} else if pft, ok := mp.(tp.ProcessFilelessType); ok {
+		// fileless executions cannot be allowed, since they are not matched by paths
+		if strings.HasPrefix(pft.Action, "Allow") {
+			return tp.MatchPolicy{}
+		}
+
+		match.Severity = strconv.Itoa(pft.Severity)
+		match.Tags = pft.Tags
+		match.Message = pft.Message
+
+		match.Operation = "Process"
+		match.Resource = getFilelessTypeFromName(pft.Type)
+		match.ResourceType = "Fileless"
+
+		// no enforcer can block fileless executions
+		if strings.HasPrefix(pft.Action, "Block") {
+			match.Action = "Audit (" + pft.Action + ")"
+		} else {
+			match.Action = pft.Action
+		}
+	} else if kot, ok := mp.(tp.KernelOperationType); ok {
+		match.Severity = strconv.Itoa(kot.Severity)
+		match.Tags = kot.Tags
+		match.Message = kot.Message
+
+		match.Operation = "Kernel"
+		match.Resource = getKernelOperationFromName(kot.Operation)
+		match.ResourceType = "Kernel"
+
+		// module loads and unloads are blocked by denying CAP_SYS_MODULE, but no enforcer can block BPF program loads
+		if policyEnabled == tp.KubeArmorPolicyAudited && strings.HasPrefix(kot.Action, "Block") {
+			match.Action = "Audit (" + kot.Action + ")"
+		} else if match.Resource == "operation=BPFProgLoad" && strings.HasPrefix(kot.Action, "Block") {
+			match.Action = "Audit (" + kot.Action + ")"
+		} else {
+			match.Action = kot.Action
+		}
+	} else if pst, ok := mp.(tp.PrivilegeSyscallType); ok {
+		match.Severity = strconv.Itoa(pst.Severity)
+		match.Tags = pst.Tags
+		match.Message = pst.Message
+
+		match.Operation = "Privilege"
+		match.Resource = getPrivilegeSyscallFromName(pst.Syscall)
+		match.ResourceType = "Syscall"
+
+		// only setns events are marked with the namespaces of the host
+		match.HostNamespace = pst.HostNamespace && match.Resource == "syscall=setns"
+
+		// setuid and mount families are blocked through capabilities, but no enforcer can block setns and unshare
+		if policyEnabled == tp.KubeArmorPolicyAudited && strings.HasPrefix(pst.Action, "Block") {
+			match.Action = "Audit (" + pst.Action + ")"
+		} else if (match.Resource == "syscall=setns" || match.Resource == "syscall=unshare") && strings.HasPrefix(pst.Action, "Block") {
+			match.Action = "Audit (" + pst.Action + ")"
+		} else {
+			match.Action = pst.Action
+		}
 	} else {
 		return tp.MatchPolicy{}
 	}
 
+	// UIDs can be audited, but cannot be blocked by enforcers (enforcers only allow the resources of the allow rules)
+	if hasFromUser(match.FromUser) && strings.HasPrefix(match.Action, "Block") {
+		match.Action = "Audit (" + match.Action + ")"
+	}
+
+	// processes are killed or stopped by KubeArmor (not by enforcers) only if the policy is enabled
+	if policyEnabled == tp.KubeArmorPolicyAudited && (match.Action == "Kill" || match.Action == "Stop") {
+		match.Action = "Audit (" + match.Action + ")"
+	}
+
 	return match
 }
 
@@ -188,6 +535,9 @@ func (fd *Feeder) UpdateSecurityPolicies(action string, endPoint tp.EndPoint) {
 	for _, secPolicy := range endPoint.SecurityPolicies {
 		policyName := secPolicy.Metadata["policyName"]
 
+		// the first match of this policy
+		firstMatch := len(matches.Policies)
+
 		if len(secPolicy.Spec.AppArmor) > 0 {
 			match := tp.MatchPolicy{}
 
@@ -202,6 +552,9 @@ func (fd *Feeder) UpdateSecurityPolicies(action string, endPoint tp.EndPoint) {
 
 			if len(path.FromSource) == 0 {
 				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, path)
+				if len(match.Resource) == 0 {
+					continue
+				}
 				matches.Policies = append(matches.Policies, match)
 				continue
 			}
@@ -210,12 +563,15 @@ func (fd *Feeder) UpdateSecurityPolicies(action string, endPoint tp.EndPoint) {
 				if len(src.Path) > 0 {
 					fromSource = src.Path
 				} else if len(src.Directory) > 0 {
-					fromSource = src.Directory
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
 				} else {
 					continue
 				}
 
 				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, path)
+				if len(match.Resource) == 0 {
+					continue
+				}
 				matches.Policies = append(matches.Policies, match)
 			}
 		}
@@ -225,6 +581,9 @@ func (fd *Feeder) UpdateSecurityPolicies(action string, endPoint tp.EndPoint) {
 
 			if len(dir.FromSource) == 0 {
 				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, dir)
+				if len(match.Resource) == 0 {
+					continue
+				}
 				matches.Policies = append(matches.Policies, match)
 				continue
 			}
@@ -233,12 +592,15 @@ func (fd *Feeder) UpdateSecurityPolicies(action string, endPoint tp.EndPoint) {
 				if len(src.Path) > 0 {
 					fromSource = src.Path
 				} else if len(src.Directory) > 0 {
-					fromSource = src.Directory
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
 				} else {
 					continue
 				}
 
 				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, dir)
+				if len(match.Resource) == 0 {
+					continue
+				}
 				matches.Policies = append(matches.Policies, match)
 			}
 		}
@@ -252,25 +614,494 @@ func (fd *Feeder) UpdateSecurityPolicies(action string, endPoint tp.EndPoint) {
 
 			match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, patt)
 
-			regexpComp, err := regexp.Compile(patt.Pattern)
+			// patterns are compiled with the same globbing as AppArmor's
+			regexpComp, err := getGlobRegexp(patt.Pattern)
 			if err != nil {
-				fd.Debugf("MatchPolicy Regexp compilation error: %s\n", patt.Pattern)
+				fd.Debugf("MatchPolicy Glob compilation error: %s\n", patt.Pattern)
 				continue
 			}
 			match.Regexp = regexpComp
-			// Using 'Glob' despite compiling 'Regexp', since we don't have
-			// a native pattern matching design yet and 'Glob' is more similar
-			// to AppArmor's pattern matching syntax.
 			match.ResourceType = "Glob"
 
 			matches.Policies = append(matches.Policies, match)
 		}
 
+		for _, fl := range secPolicy.Spec.Process.MatchFileless {
+			if len(fl.Type) == 0 {
+				continue
+			}
+
+			fromSource := ""
+
+			if len(fl.FromSource) == 0 {
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, fl)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range fl.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else if len(src.Directory) > 0 {
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, fl)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+			}
+		}
+
 		for _, path := range secPolicy.Spec.File.MatchPaths {
 			fromSource := ""
 
 			if len(path.FromSource) == 0 {
 				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, path)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range path.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else if len(src.Directory) > 0 {
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, path)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+			}
+		}
+
+		for _, dir := range secPolicy.Spec.File.MatchDirectories {
+			fromSource := ""
+
+			if len(dir.FromSource) == 0 {
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, dir)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range dir.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else if len(src.Directory) > 0 {
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, dir)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+			}
+		}
+
+		for _, patt := range secPolicy.Spec.File.MatchPatterns {
+			if len(patt.Pattern) == 0 {
+				continue
+			}
+
+			fromSource := ""
+
+			match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, patt)
+
+			// patterns are compiled with the same globbing as AppArmor's
+			regexpComp, err := getGlobRegexp(patt.Pattern)
+			if err != nil {
+				fd.Debugf("MatchPolicy Glob compilation error: %s\n", patt.Pattern)
+				continue
+			}
+			match.Regexp = regexpComp
+			match.ResourceType = "Glob"
+
+			matches.Policies = append(matches.Policies, match)
+		}
+
+		for _, proto := range secPolicy.Spec.Network.MatchProtocols {
+			if len(proto.Protocol) == 0 {
+				continue
+			}
+
+			fromSource := ""
+
+			if len(proto.FromSource) == 0 {
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, proto)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range proto.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else if len(src.Directory) > 0 {
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, proto)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+			}
+
+		}
+
+		for _, ip := range secPolicy.Spec.Network.MatchIPs {
+			if len(ip.IP) == 0 {
+				continue
+			}
+
+			fromSource := ""
+
+			if len(ip.FromSource) == 0 {
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, ip)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range ip.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else if len(src.Directory) > 0 {
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, ip)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+			}
+
+		}
+
+		for _, port := range secPolicy.Spec.Network.MatchPorts {
+			if port.Port == 0 {
+				continue
+			}
+
+			fromSource := ""
+
+			if len(port.FromSource) == 0 {
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, port)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range port.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else if len(src.Directory) > 0 {
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, port)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+			}
+
+		}
+
+		for _, domain := range secPolicy.Spec.Network.MatchDomains {
+			if len(domain.Domain) == 0 {
+				continue
+			}
+
+			fromSource := ""
+
+			if len(domain.FromSource) == 0 {
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, domain)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range domain.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else if len(src.Directory) > 0 {
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, domain)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+			}
+
+		}
+
+		for _, cap := range secPolicy.Spec.Capabilities.MatchCapabilities {
+			if len(cap.Capability) == 0 {
+				continue
+			}
+
+			fromSource := ""
+
+			if len(cap.FromSource) == 0 {
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, cap)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range cap.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else if len(src.Directory) > 0 {
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, cap)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+			}
+
+		}
+
+		for _, sc := range secPolicy.Spec.Privilege.MatchSyscalls {
+			if len(sc.Syscall) == 0 {
+				continue
+			}
+
+			fromSource := ""
+
+			if len(sc.FromSource) == 0 {
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, sc)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range sc.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else if len(src.Directory) > 0 {
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, sc)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+			}
+		}
+
+		// the matches of this policy are only applied to the given containers
+		for idx := firstMatch; idx < len(matches.Policies); idx++ {
+			matches.Policies[idx].Containers = secPolicy.Spec.Selector.Containers
+			matches.Policies[idx].Response = secPolicy.Spec.Response
+		}
+	}
+
+	// index the policies not to check all of them for each log
+	matches.Index = newMatchPolicyIndex(matches.Policies)
+
+	fd.SecurityPoliciesLock.Lock()
+	fd.SecurityPolicies[name] = matches
+	fd.SecurityPoliciesLock.Unlock()
+}
+
+// ============================ //
+// == Host Security Policies == //
+// ============================ //
+
+// UpdateHostSecurityPolicies Function
+func (fd *Feeder) UpdateHostSecurityPolicies(action string, secPolicies []tp.HostSecurityPolicy) {
+	if action == "DELETED" {
+		delete(fd.SecurityPolicies, fd.HostName)
+		return
+	}
+
+	// ADDED | MODIFIED
+	matches := tp.MatchPolicies{}
+
+	for _, secPolicy := range secPolicies {
+		policyName := secPolicy.Metadata["policyName"]
+
+		if len(secPolicy.Spec.AppArmor) > 0 {
+			match := tp.MatchPolicy{}
+
+			match.PolicyName = policyName
+			match.Native = true
+
+			matches.Policies = append(matches.Policies, match)
+		}
+
+		for _, path := range secPolicy.Spec.Process.MatchPaths {
+			fromSource := ""
+
+			if len(path.FromSource) == 0 {
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, path)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range path.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else if len(src.Directory) > 0 {
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, path)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+			}
+		}
+
+		for _, dir := range secPolicy.Spec.Process.MatchDirectories {
+			fromSource := ""
+
+			if len(dir.FromSource) == 0 {
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, dir)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range dir.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else if len(src.Directory) > 0 {
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, dir)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+			}
+		}
+
+		for _, patt := range secPolicy.Spec.Process.MatchPatterns {
+			if len(patt.Pattern) == 0 {
+				continue
+			}
+
+			fromSource := ""
+
+			match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, patt)
+
+			// patterns are compiled with the same globbing as AppArmor's
+			regexpComp, err := getGlobRegexp(patt.Pattern)
+			if err != nil {
+				fd.Debugf("MatchPolicy Glob compilation error: %s\n", patt.Pattern)
+				continue
+			}
+			match.Regexp = regexpComp
+			match.ResourceType = "Glob"
+
+			matches.Policies = append(matches.Policies, match)
+		}
+
+		for _, fl := range secPolicy.Spec.Process.MatchFileless {
+			if len(fl.Type) == 0 {
+				continue
+			}
+
+			fromSource := ""
+
+			if len(fl.FromSource) == 0 {
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, fl)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range fl.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else if len(src.Directory) > 0 {
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, fl)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+			}
+		}
+
+		for _, path := range secPolicy.Spec.File.MatchPaths {
+			fromSource := ""
+
+			if len(path.FromSource) == 0 {
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, path)
+				if len(match.Resource) == 0 {
+					continue
+				}
 				matches.Policies = append(matches.Policies, match)
 				continue
 			}
@@ -279,12 +1110,15 @@ func (fd *Feeder) UpdateSecurityPolicies(action string, endPoint tp.EndPoint) {
 				if len(src.Path) > 0 {
 					fromSource = src.Path
 				} else if len(src.Directory) > 0 {
-					fromSource = src.Directory
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
 				} else {
 					continue
 				}
 
-				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, path)
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, path)
+				if len(match.Resource) == 0 {
+					continue
+				}
 				matches.Policies = append(matches.Policies, match)
 			}
 		}
@@ -293,7 +1127,10 @@ func (fd *Feeder) UpdateSecurityPolicies(action string, endPoint tp.EndPoint) {
 			fromSource := ""
 
 			if len(dir.FromSource) == 0 {
-				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, dir)
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, dir)
+				if len(match.Resource) == 0 {
+					continue
+				}
 				matches.Policies = append(matches.Policies, match)
 				continue
 			}
@@ -302,12 +1139,15 @@ func (fd *Feeder) UpdateSecurityPolicies(action string, endPoint tp.EndPoint) {
 				if len(src.Path) > 0 {
 					fromSource = src.Path
 				} else if len(src.Directory) > 0 {
-					fromSource = src.Directory
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
 				} else {
 					continue
 				}
 
-				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, dir)
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, dir)
+				if len(match.Resource) == 0 {
+					continue
+				}
 				matches.Policies = append(matches.Policies, match)
 			}
 		}
@@ -319,17 +1159,15 @@ func (fd *Feeder) UpdateSecurityPolicies(action string, endPoint tp.EndPoint) {
 
 			fromSource := ""
 
-			match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, patt)
+			match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, patt)
 
-			regexpComp, err := regexp.Compile(patt.Pattern)
+			// patterns are compiled with the same globbing as AppArmor's
+			regexpComp, err := getGlobRegexp(patt.Pattern)
 			if err != nil {
-				fd.Debugf("MatchPolicy Regexp compilation error: %s\n", patt.Pattern)
+				fd.Debugf("MatchPolicy Glob compilation error: %s\n", patt.Pattern)
 				continue
 			}
 			match.Regexp = regexpComp
-			// Using 'Glob' despite compiling 'Regexp', since we don't have
-			// a native pattern matching design yet and 'Glob' is more similar
-			// to AppArmor's pattern matching syntax.
 			match.ResourceType = "Glob"
 
 			matches.Policies = append(matches.Policies, match)
@@ -343,7 +1181,7 @@ func (fd *Feeder) UpdateSecurityPolicies(action string, endPoint tp.EndPoint) {
 			fromSource := ""
 
 			if len(proto.FromSource) == 0 {
-				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, proto)
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, proto)
 				if len(match.Resource) == 0 {
 					continue
 				}
@@ -355,12 +1193,12 @@ func (fd *Feeder) UpdateSecurityPolicies(action string, endPoint tp.EndPoint) {
 				if len(src.Path) > 0 {
 					fromSource = src.Path
 				} else if len(src.Directory) > 0 {
-					fromSource = src.Directory
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
 				} else {
 					continue
 				}
 
-				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, proto)
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, proto)
 				if len(match.Resource) == 0 {
 					continue
 				}
@@ -369,15 +1207,15 @@ func (fd *Feeder) UpdateSecurityPolicies(action string, endPoint tp.EndPoint) {
 
 		}
 
-		for _, cap := range secPolicy.Spec.Capabilities.MatchCapabilities {
-			if len(cap.Capability) == 0 {
+		for _, ip := range secPolicy.Spec.Network.MatchIPs {
+			if len(ip.IP) == 0 {
 				continue
 			}
 
 			fromSource := ""
 
-			if len(cap.FromSource) == 0 {
-				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, cap)
+			if len(ip.FromSource) == 0 {
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, ip)
 				if len(match.Resource) == 0 {
 					continue
 				}
@@ -385,16 +1223,16 @@ func (fd *Feeder) UpdateSecurityPolicies(action string, endPoint tp.EndPoint) {
 				continue
 			}
 
-			for _, src := range cap.FromSource {
+			for _, src := range ip.FromSource {
 				if len(src.Path) > 0 {
 					fromSource = src.Path
 				} else if len(src.Directory) > 0 {
-					fromSource = src.Directory
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
 				} else {
 					continue
 				}
 
-				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, cap)
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, ip)
 				if len(match.Resource) == 0 {
 					continue
 				}
@@ -402,186 +1240,117 @@ func (fd *Feeder) UpdateSecurityPolicies(action string, endPoint tp.EndPoint) {
 			}
 
 		}
-	}
-
-	fd.SecurityPoliciesLock.Lock()
-	fd.SecurityPolicies[name] = matches
-	fd.SecurityPoliciesLock.Unlock()
-}
-
-// ============================ //
-// == Host Security Policies == //
-// ============================ //
-
-// UpdateHostSecurityPolicies Function
-func (fd *Feeder) UpdateHostSecurityPolicies(action string, secPolicies []tp.HostSecurityPolicy) {
-	if action == "DELETED" {
-		delete(fd.SecurityPolicies, fd.HostName)
-		return
-	}
-
-	// ADDED | MODIFIED
-	matches := tp.MatchPolicies{}
-
-	for _, secPolicy := range secPolicies {
-		policyName := secPolicy.Metadata["policyName"]
-
-		if len(secPolicy.Spec.AppArmor) > 0 {
-			match := tp.MatchPolicy{}
-
-			match.PolicyName = policyName
-			match.Native = true
-
-			matches.Policies = append(matches.Policies, match)
-		}
-
-		for _, path := range secPolicy.Spec.Process.MatchPaths {
-			fromSource := ""
 
-			if len(path.FromSource) == 0 {
-				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, path)
-				matches.Policies = append(matches.Policies, match)
+		for _, port := range secPolicy.Spec.Network.MatchPorts {
+			if port.Port == 0 {
 				continue
 			}
 
-			for _, src := range path.FromSource {
-				if len(src.Path) > 0 {
-					fromSource = src.Path
-				} else if len(src.Directory) > 0 {
-					fromSource = src.Directory
-				} else {
-					continue
-				}
-
-				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, path)
-				matches.Policies = append(matches.Policies, match)
-			}
-		}
-
-		for _, dir := range secPolicy.Spec.Process.MatchDirectories {
 			fromSource := ""
 
-			if len(dir.FromSource) == 0 {
-				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, dir)
+			if len(port.FromSource) == 0 {
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, port)
+				if len(match.Resource) == 0 {
+					continue
+				}
 				matches.Policies = append(matches.Policies, match)
 				continue
 			}
 
-			for _, src := range dir.FromSource {
+			for _, src := range port.FromSource {
 				if len(src.Path) > 0 {
 					fromSource = src.Path
 				} else if len(src.Directory) > 0 {
-					fromSource = src.Directory
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
 				} else {
 					continue
 				}
 
-				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, dir)
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, port)
+				if len(match.Resource) == 0 {
+					continue
+				}
 				matches.Policies = append(matches.Policies, match)
 			}
-		}
-
-		for _, patt := range secPolicy.Spec.Process.MatchPatterns {
-			if len(patt.Pattern) == 0 {
-				continue
-			}
-
-			fromSource := ""
 
-			match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, patt)
+		}
 
-			regexpComp, err := regexp.Compile(patt.Pattern)
-			if err != nil {
-				fd.Debugf("MatchPolicy Regexp compilation error: %s\n", patt.Pattern)
+		for _, domain := range secPolicy.Spec.Network.MatchDomains {
+			if len(domain.Domain) == 0 {
 				continue
 			}
-			match.Regexp = regexpComp
-			// Using 'Glob' despite compiling 'Regexp', since we don't have
-			// a native pattern matching design yet and 'Glob' is more similar
-			// to AppArmor's pattern matching syntax.
-			match.ResourceType = "Glob"
-
-			matches.Policies = append(matches.Policies, match)
-		}
 
-		for _, path := range secPolicy.Spec.File.MatchPaths {
 			fromSource := ""
 
-			if len(path.FromSource) == 0 {
-				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, path)
+			if len(domain.FromSource) == 0 {
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, domain)
+				if len(match.Resource) == 0 {
+					continue
+				}
 				matches.Policies = append(matches.Policies, match)
 				continue
 			}
 
-			for _, src := range path.FromSource {
+			for _, src := range domain.FromSource {
 				if len(src.Path) > 0 {
 					fromSource = src.Path
 				} else if len(src.Directory) > 0 {
-					fromSource = src.Directory
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
 				} else {
 					continue
 				}
 
-				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, path)
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, domain)
+				if len(match.Resource) == 0 {
+					continue
+				}
 				matches.Policies = append(matches.Policies, match)
 			}
+
 		}
 
-		for _, dir := range secPolicy.Spec.File.MatchDirectories {
+		for _, cap := range secPolicy.Spec.Capabilities.MatchCapabilities {
+			if len(cap.Capability) == 0 {
+				continue
+			}
+
 			fromSource := ""
 
-			if len(dir.FromSource) == 0 {
-				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, dir)
+			if len(cap.FromSource) == 0 {
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, cap)
+				if len(match.Resource) == 0 {
+					continue
+				}
 				matches.Policies = append(matches.Policies, match)
 				continue
 			}
 
-			for _, src := range dir.FromSource {
+			for _, src := range cap.FromSource {
 				if len(src.Path) > 0 {
 					fromSource = src.Path
 				} else if len(src.Directory) > 0 {
-					fromSource = src.Directory
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
 				} else {
 					continue
 				}
 
-				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, dir)
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, cap)
+				if len(match.Resource) == 0 {
+					continue
+				}
 				matches.Policies = append(matches.Policies, match)
 			}
 		}
 
-		for _, patt := range secPolicy.Spec.File.MatchPatterns {
-			if len(patt.Pattern) == 0 {
-				continue
-			}
-
-			fromSource := ""
-
-			match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, patt)
-
-			regexpComp, err := regexp.Compile(patt.Pattern)
-			if err != nil {
-				fd.Debugf("MatchPolicy Regexp compilation error: %s\n", patt.Pattern)
-				continue
-			}
-			match.Regexp = regexpComp
-			// Using 'Glob' despite compiling 'Regexp', since we don't have
-			// a native pattern matching design yet and 'Glob' is more similar
-			// to AppArmor's pattern matching syntax.
-			match.ResourceType = "Glob"
-
-			matches.Policies = append(matches.Policies, match)
-		}
-
-		for _, proto := range secPolicy.Spec.Network.MatchProtocols {
-			if len(proto.Protocol) == 0 {
+		for _, op := range secPolicy.Spec.Kernel.MatchOperations {
+			if len(op.Operation) == 0 {
 				continue
 			}
 
 			fromSource := ""
 
-			if len(proto.FromSource) == 0 {
-				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, proto)
+			if len(op.FromSource) == 0 {
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, op)
 				if len(match.Resource) == 0 {
 					continue
 				}
@@ -589,33 +1358,32 @@ func (fd *Feeder) UpdateHostSecurityPolicies(action string, secPolicies []tp.Hos
 				continue
 			}
 
-			for _, src := range proto.FromSource {
+			for _, src := range op.FromSource {
 				if len(src.Path) > 0 {
 					fromSource = src.Path
 				} else if len(src.Directory) > 0 {
-					fromSource = src.Directory
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
 				} else {
 					continue
 				}
 
-				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, proto)
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, op)
 				if len(match.Resource) == 0 {
 					continue
 				}
 				matches.Policies = append(matches.Policies, match)
 			}
-
 		}
 
-		for _, cap := range secPolicy.Spec.Capabilities.MatchCapabilities {
-			if len(cap.Capability) == 0 {
+		for _, sc := range secPolicy.Spec.Privilege.MatchSyscalls {
+			if len(sc.Syscall) == 0 {
 				continue
 			}
 
 			fromSource := ""
 
-			if len(cap.FromSource) == 0 {
-				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, cap)
+			if len(sc.FromSource) == 0 {
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, sc)
 				if len(match.Resource) == 0 {
 					continue
 				}
@@ -623,16 +1391,16 @@ func (fd *Feeder) UpdateHostSecurityPolicies(action string, secPolicies []tp.Hos
 				continue
 			}
 
-			for _, src := range cap.FromSource {
+			for _, src := range sc.FromSource {
 				if len(src.Path) > 0 {
 					fromSource = src.Path
 				} else if len(src.Directory) > 0 {
-					fromSource = src.Directory
+					fromSource = getDirectoryPattern(src.Directory, src.Recursive)
 				} else {
 					continue
 				}
 
-				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, cap)
+				match := fd.newMatchPolicy(fd.HostPolicyEnabled, policyName, fromSource, sc)
 				if len(match.Resource) == 0 {
 					continue
 				}
@@ -641,6 +1409,9 @@ func (fd *Feeder) UpdateHostSecurityPolicies(action string, secPolicies []tp.Hos
 		}
 	}
 
+	// index the policies not to check all of them for each log
+	matches.Index = newMatchPolicyIndex(matches.Policies)
+
 	fd.SecurityPoliciesLock.Lock()
 	fd.SecurityPolicies[fd.HostName] = matches
 	fd.SecurityPoliciesLock.Unlock()
@@ -650,183 +1421,124 @@ func (fd *Feeder) UpdateHostSecurityPolicies(action string, secPolicies []tp.Hos
 // == Policy Matches == //
 // ==================== //
 
-// UpdateMatchedPolicy Function
-func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
-	allowProcPolicy := ""
-	allowProcPolicySeverity := ""
-	allowProcTags := []string{}
-	allowProcMessage := ""
-
-	allowFilePolicy := ""
-	allowFilePolicySeverity := ""
-	allowFileTags := []string{}
-	allowFileMessage := ""
-
-	allowNetworkPolicy := ""
-	allowNetworkPolicySeverity := ""
-	allowNetworkTags := []string{}
-	allowNetworkMessage := ""
-
-	mightBeNative := false
-
-	if log.Result == "Passed" || log.Result == "Operation not permitted" || log.Result == "Permission denied" {
-		fd.SecurityPoliciesLock.RLock()
+// allowPolicy Structure
+type allowPolicy struct {
+	PolicyName string
+	Severity   string
+	Tags       []string
+	Message    string
+}
 
-		key := fd.HostName
+// addPolicy Function
+func (ap *allowPolicy) addPolicy(secPolicy tp.MatchPolicy) {
+	if ap.PolicyName == "" {
+		ap.PolicyName = secPolicy.PolicyName
+		ap.Severity = secPolicy.Severity
 
-		if log.NamespaceName != "" && log.PodName != "" {
-			key = log.NamespaceName + "_" + log.PodName
+		for _, tag := range secPolicy.Tags {
+			if !kl.ContainsElement(ap.Tags, tag) {
+				ap.Tags = append(ap.Tags, tag)
+			}
 		}
 
-		secPolicies := fd.SecurityPolicies[key].Policies
-		for _, secPolicy := range secPolicies {
-			if secPolicy.Source == "" || strings.Contains(secPolicy.Source, strings.Split(log.Source, " ")[0]) || (log.Source == "runc:[2:INIT]" && strings.Contains(secPolicy.Source, strings.Split(log.Resource, " ")[0])) {
-				if secPolicy.Action == "Allow" {
-					if secPolicy.Operation == "Process" {
-						if allowProcPolicy == "" {
-							allowProcPolicy = secPolicy.PolicyName
-							allowProcPolicySeverity = secPolicy.Severity
-
-							for _, tag := range secPolicy.Tags {
-								if !kl.ContainsElement(allowProcTags, tag) {
-									allowProcTags = append(allowProcTags, tag)
-								}
-							}
-
-							allowProcMessage = secPolicy.Message
-						} else if !strings.Contains(allowProcPolicy, secPolicy.PolicyName) {
-							allowProcPolicy = allowProcPolicy + "," + secPolicy.PolicyName
-							allowProcPolicySeverity = allowProcPolicySeverity + "," + secPolicy.Severity
-
-							for _, tag := range secPolicy.Tags {
-								if !kl.ContainsElement(allowProcTags, tag) {
-									allowProcTags = append(allowProcTags, tag)
-								}
-							}
-
-							allowProcMessage = allowProcMessage + "," + secPolicy.Message
-						}
-					} else if secPolicy.Operation == "File" {
-						if allowFilePolicy == "" {
-							allowFilePolicy = secPolicy.PolicyName
-							allowFilePolicySeverity = secPolicy.Severity
-
-							for _, tag := range secPolicy.Tags {
-								if !kl.ContainsElement(allowFileTags, tag) {
-									allowFileTags = append(allowFileTags, tag)
-								}
-							}
-
-							allowFileMessage = secPolicy.Message
-						} else if !strings.Contains(allowFilePolicy, secPolicy.PolicyName) {
-							allowFilePolicy = allowFilePolicy + "," + secPolicy.PolicyName
-							allowFilePolicySeverity = allowFilePolicySeverity + "," + secPolicy.Severity
-
-							for _, tag := range secPolicy.Tags {
-								if !kl.ContainsElement(allowFileTags, tag) {
-									allowFileTags = append(allowFileTags, tag)
-								}
-							}
-
-							allowFileMessage = allowFileMessage + "," + secPolicy.Message
-						}
-					} else if secPolicy.Operation == "Network" {
-						if allowNetworkPolicy == "" {
-							allowNetworkPolicy = secPolicy.PolicyName
-							allowNetworkPolicySeverity = secPolicy.Severity
-
-							for _, tag := range secPolicy.Tags {
-								if !kl.ContainsElement(allowNetworkTags, tag) {
-									allowNetworkTags = append(allowNetworkTags, tag)
-								}
-							}
-
-							allowNetworkMessage = secPolicy.Message
-						} else if !strings.Contains(allowNetworkPolicy, secPolicy.PolicyName) {
-							allowNetworkPolicy = allowNetworkPolicy + "," + secPolicy.PolicyName
-							allowNetworkPolicySeverity = allowNetworkPolicySeverity + "," + secPolicy.Severity
-
-							for _, tag := range secPolicy.Tags {
-								if !kl.ContainsElement(allowNetworkTags, tag) {
-									allowNetworkTags = append(allowNetworkTags, tag)
-								}
-							}
-
-							allowNetworkMessage = allowNetworkMessage + "," + secPolicy.Message
-						}
-					}
-				}
+		ap.Message = secPolicy.Message
+	} else if !strings.Contains(ap.PolicyName, secPolicy.PolicyName) {
+		ap.PolicyName = ap.PolicyName + "," + secPolicy.PolicyName
+		ap.Severity = ap.Severity + "," + secPolicy.Severity
+
+		for _, tag := range secPolicy.Tags {
+			if !kl.ContainsElement(ap.Tags, tag) {
+				ap.Tags = append(ap.Tags, tag)
 			}
+		}
 
-			switch log.Operation {
-			case "Process", "File":
-				if secPolicy.Operation == log.Operation {
-					matched := false
+		ap.Message = ap.Message + "," + secPolicy.Message
+	}
+}
 
-					switch secPolicy.ResourceType {
-					case "Glob":
-						// Match using a globbing syntax very similar to the AppArmor's
-						matched, _ = filepath.Match(secPolicy.Resource, log.Resource) // pattern (secPolicy.Resource) -> string (log.Resource)
-					case "Regexp":
-						if secPolicy.Regexp != nil {
-							// Match using compiled regular expression
-							matched = secPolicy.Regexp.MatchString(log.Resource) // regexp (secPolicy.Regexp) -> string (log.Resource)
-						}
-					}
+// matchResult Structure
+type matchResult struct {
+	AllowProc    allowPolicy
+	AllowFile    allowPolicy
+	AllowNetwork allowPolicy
 
-					if matched || strings.Contains(log.Resource, secPolicy.Resource) {
-						if secPolicy.Source == "" || (secPolicy.Source != "" && strings.Contains(secPolicy.Source, strings.Split(log.Source, " ")[0])) || (secPolicy.Source != "" && log.Source == "runc:[2:INIT]" && strings.Contains(secPolicy.Source, strings.Split(log.Resource, " ")[0])) {
-							log.PolicyName = secPolicy.PolicyName
-							log.Severity = secPolicy.Severity
+	// allow rules whose resources are matched, but whose arguments or users are not (enforcers only allow the resources)
+	AllowNarrowed allowPolicy
 
-							if len(secPolicy.Tags) > 0 {
-								log.Tags = strings.Join(secPolicy.Tags[:], ",")
-							}
+	// IP and port rules in allow policies (not enforced by any enforcer)
+	AllowNetworkAddr bool
 
-							if len(secPolicy.Message) > 0 {
-								log.Message = secPolicy.Message
-							}
+	// domain rules in allow policies (not enforced by any enforcer)
+	AllowNetworkDomain bool
 
-							log.Type = "MatchedPolicy"
-							log.Action = secPolicy.Action
+	MightBeNative bool
+}
 
-							continue
-						}
-					}
-				}
-			case "Network":
-				if secPolicy.Operation == log.Operation {
-					if strings.Contains(log.Resource, secPolicy.Resource) {
-						if secPolicy.Source == "" || (secPolicy.Source != "" && strings.Contains(secPolicy.Source, strings.Split(log.Source, " ")[0])) {
-							log.PolicyName = secPolicy.PolicyName
-							log.Severity = secPolicy.Severity
+// addAllowPolicy Function
+func (result *matchResult) addAllowPolicy(secPolicy tp.MatchPolicy) {
+	if secPolicy.Operation == "Process" {
+		result.AllowProc.addPolicy(secPolicy)
+	} else if secPolicy.Operation == "File" {
+		result.AllowFile.addPolicy(secPolicy)
+	} else if secPolicy.Operation == "Network" {
+		if secPolicy.ResourceType == "IP" || secPolicy.ResourceType == "Port" {
+			result.AllowNetworkAddr = true
+		} else if secPolicy.ResourceType == "Domain" {
+			result.AllowNetworkDomain = true
+		}
 
-							if len(secPolicy.Tags) > 0 {
-								log.Tags = strings.Join(secPolicy.Tags[:], ",")
-							}
+		result.AllowNetwork.addPolicy(secPolicy)
+	}
+}
 
-							if len(secPolicy.Message) > 0 {
-								log.Message = secPolicy.Message
-							}
+// UpdateMatchedPolicy Function
+func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
+	result := matchResult{}
 
-							log.Type = "MatchedPolicy"
-							log.Action = secPolicy.Action
+	if log.Result == "Passed" || log.Result == "Operation not permitted" || log.Result == "Permission denied" {
+		fd.SecurityPoliciesLock.RLock()
 
-							continue
-						}
-					}
-				}
-			}
+		key := fd.HostName
 
-			if secPolicy.Native && log.Result != "Passed" {
-				mightBeNative = true
-				continue
-			}
+		if log.NamespaceName != "" && log.PodName != "" {
+			key = log.NamespaceName + "_" + log.PodName
 		}
 
+		log, result = matchPolicies(fd.SecurityPolicies[key], log)
+
 		fd.SecurityPoliciesLock.RUnlock()
 	}
 
+	return getMatchedLog(log, result)
+}
+
+// getMatchedLog Function
+func getMatchedLog(log tp.Log, result matchResult) tp.Log {
+	allowProcPolicy := result.AllowProc.PolicyName
+	allowProcPolicySeverity := result.AllowProc.Severity
+	allowProcTags := result.AllowProc.Tags
+	allowProcMessage := result.AllowProc.Message
+
+	allowFilePolicy := result.AllowFile.PolicyName
+	allowFilePolicySeverity := result.AllowFile.Severity
+	allowFileTags := result.AllowFile.Tags
+	allowFileMessage := result.AllowFile.Message
+
+	allowNetworkPolicy := result.AllowNetwork.PolicyName
+	allowNetworkPolicySeverity := result.AllowNetwork.Severity
+	allowNetworkTags := result.AllowNetwork.Tags
+	allowNetworkMessage := result.AllowNetwork.Message
+
+	allowNarrowedPolicy := result.AllowNarrowed.PolicyName
+	allowNarrowedPolicySeverity := result.AllowNarrowed.Severity
+	allowNarrowedTags := result.AllowNarrowed.Tags
+	allowNarrowedMessage := result.AllowNarrowed.Message
+
+	allowNetworkAddr := result.AllowNetworkAddr
+	allowNetworkDomain := result.AllowNetworkDomain
+
+	mightBeNative := result.MightBeNative
+
 	if log.ContainerID != "" { // container
 		if log.Type == "" {
 			if mightBeNative && log.Result != "Passed" {
@@ -950,6 +1662,46 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 				}
 			}
 
+			if log.Operation == "Network" && (allowNetworkAddr || allowNetworkDomain) && allowNetworkPolicy != "" {
+				// audit the connections to the addresses and the queries for the domains not allowed since no enforcer can block them
+				if ip, _ := getIPAndPortFromResource(log.Resource); (allowNetworkAddr && ip != nil) || (allowNetworkDomain && getDomainFromResource(log.Resource) != "") {
+					log.PolicyName = allowNetworkPolicy
+					log.Severity = allowNetworkPolicySeverity
+
+					if len(allowNetworkTags) > 0 {
+						log.Tags = strings.Join(allowNetworkTags[:], ",")
+					}
+
+					if len(allowNetworkMessage) > 0 {
+						log.Message = allowNetworkMessage
+					}
+
+					log.Type = "MatchedPolicy"
+					log.Action = "Audit (Allow)"
+
+					return log
+				}
+			}
+
+			if allowNarrowedPolicy != "" && log.Result == "Passed" {
+				// audit the operations allowed by enforcers, but not by the arguments or the users of the allow rules
+				log.PolicyName = allowNarrowedPolicy
+				log.Severity = allowNarrowedPolicySeverity
+
+				if len(allowNarrowedTags) > 0 {
+					log.Tags = strings.Join(allowNarrowedTags[:], ",")
+				}
+
+				if len(allowNarrowedMessage) > 0 {
+					log.Message = allowNarrowedMessage
+				}
+
+				log.Type = "MatchedPolicy"
+				log.Action = "Audit (Allow)"
+
+				return log
+			}
+
 			if log.Result != "Passed" {
 				log.Type = "ContainerLog"
 				return log
@@ -996,6 +1748,25 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 				return log
 			}
 
+			if allowNarrowedPolicy != "" && log.Result == "Passed" {
+				// audit the operations allowed by enforcers, but not by the arguments or the users of the allow rules
+				log.PolicyName = allowNarrowedPolicy
+				log.Severity = allowNarrowedPolicySeverity
+
+				if len(allowNarrowedTags) > 0 {
+					log.Tags = strings.Join(allowNarrowedTags[:], ",")
+				}
+
+				if len(allowNarrowedMessage) > 0 {
+					log.Message = allowNarrowedMessage
+				}
+
+				log.Type = "MatchedHostPolicy"
+				log.Action = "Audit (Allow)"
+
+				return log
+			}
+
 			//
 
 			if log.Result != "Passed" {
@@ -1003,6 +1774,12 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 				return log
 			}
 
+			// kernel module and BPF program loads are always reported
+			if log.Operation == "Kernel" {
+				log.Type = "HostLog"
+				return log
+			}
+
 		} else if log.Type == "MatchedPolicy" {
 			if log.PolicyEnabled == tp.KubeArmorPolicyAudited {
 				if log.Action == "Block" {
@@ -0,0 +1,318 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package feeder
+
+import (
+	"net"
+	"testing"
+
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+func TestGetIPNetFromName(t *testing.T) {
+	cases := []struct {
+		name   string
+		ipNet  string
+		inside []string
+		out    []string
+	}{
+		{"10.0.0.1", "10.0.0.1/32", []string{"10.0.0.1"}, []string{"10.0.0.2"}},
+		{"10.0.0.0/8", "10.0.0.0/8", []string{"10.1.2.3", "10.255.255.255"}, []string{"11.0.0.1"}},
+		{"192.168.1.17/24", "192.168.1.0/24", []string{"192.168.1.1"}, []string{"192.168.2.1"}},
+		{"::1", "::1/128", []string{"::1"}, []string{"::2"}},
+		{"fd00::1", "fd00::1/128", []string{"fd00::1"}, []string{"fd00::2"}},
+		{"fd00::/64", "fd00::/64", []string{"fd00::1234"}, []string{"fd00:0:0:1::1"}},
+		{"invalid", "", nil, nil},
+	}
+
+	for _, c := range cases {
+		ipNet := getIPNetFromName(c.name)
+
+		if c.ipNet == "" {
+			if ipNet != nil {
+				t.Errorf("[FAIL] Parsed an invalid address %s (%s)", c.name, ipNet.String())
+				return
+			}
+			continue
+		}
+
+		if ipNet == nil || ipNet.String() != c.ipNet {
+			t.Errorf("[FAIL] Failed to parse %s (expected: %s, got: %v)", c.name, c.ipNet, ipNet)
+			return
+		}
+
+		for _, ip := range c.inside {
+			if !ipNet.Contains(net.ParseIP(ip)) {
+				t.Errorf("[FAIL] %s does not contain %s", c.ipNet, ip)
+				return
+			}
+		}
+
+		for _, ip := range c.out {
+			if ipNet.Contains(net.ParseIP(ip)) {
+				t.Errorf("[FAIL] %s contains %s", c.ipNet, ip)
+				return
+			}
+		}
+	}
+
+	t.Log("[PASS] Parsed IP addresses and CIDRs")
+}
+
+func TestMatchNetworkPolicies(t *testing.T) {
+	cases := []struct {
+		resourceType string
+		ipNet        string
+		ports        []int
+		resource     string
+		matched      bool
+	}{
+		{"IP", "10.0.0.0/8", nil, "sa_family=AF_INET sin_port=53 sin_addr=10.1.2.3", true},
+		{"IP", "10.0.0.0/8", nil, "sa_family=AF_INET sin_port=53 sin_addr=172.16.0.1", false},
+		{"IP", "10.1.2.3", nil, "sa_family=AF_INET sin_port=53 sin_addr=10.1.2.3", true},
+		{"IP", "10.1.2.3", nil, "sa_family=AF_INET sin_port=53 sin_addr=10.1.2.4", false},
+		{"IP", "10.0.0.0/8", []int{53}, "sa_family=AF_INET sin_port=53 sin_addr=10.1.2.3", true},
+		{"IP", "10.0.0.0/8", []int{80, 443}, "sa_family=AF_INET sin_port=53 sin_addr=10.1.2.3", false},
+		{"IP", "10.0.0.0/8", nil, "domain=AF_INET type=SOCK_STREAM protocol=0", false},
+		{"Port", "", []int{80, 443}, "sa_family=AF_INET sin_port=443 sin_addr=192.168.1.1", true},
+		{"Port", "", []int{80, 443}, "sa_family=AF_INET sin_port=8080 sin_addr=192.168.1.1", false},
+		{"Port", "", []int{80}, "domain=AF_INET type=SOCK_STREAM protocol=0", false},
+	}
+
+	for _, c := range cases {
+		secPolicy := tp.MatchPolicy{
+			PolicyName:   "ksp-network",
+			Action:       "Block",
+			Operation:    "Network",
+			ResourceType: c.resourceType,
+			Ports:        c.ports,
+		}
+
+		if c.ipNet != "" {
+			secPolicy.IPNet = getIPNetFromName(c.ipNet)
+		}
+
+		matches := tp.MatchPolicies{Policies: []tp.MatchPolicy{secPolicy}}
+		matches.Index = newMatchPolicyIndex(matches.Policies)
+
+		log, _ := matchPolicies(matches, tp.Log{Operation: "Network", Source: "/usr/bin/curl", Resource: c.resource})
+
+		if matched := log.PolicyName == secPolicy.PolicyName; matched != c.matched {
+			t.Errorf("[FAIL] %s %s %v (expected: %v, got: %v)", c.resourceType, c.ipNet, c.ports, c.matched, matched)
+			return
+		}
+	}
+
+	t.Log("[PASS] Matched network policies by CIDRs and ports")
+}
+
+func TestMatchHostNamespace(t *testing.T) {
+	fd := &Feeder{}
+
+	cases := []struct {
+		syscall       string
+		hostNamespace bool
+		resource      string
+		matched       bool
+	}{
+		{"setns", false, "syscall=setns nstype=CLONE_NEWNS", true},
+		{"setns", false, "syscall=setns nstype=CLONE_NEWNS namespace=host", true},
+		{"setns", true, "syscall=setns nstype=CLONE_NEWNS", false},
+		{"setns", true, "syscall=setns nstype=CLONE_NEWNS path=/proc/1/ns/mnt namespace=host", true},
+		{"unshare", true, "syscall=unshare flags=CLONE_NEWNS", true},
+	}
+
+	for _, c := range cases {
+		secPolicy := fd.newMatchPolicy(tp.KubeArmorPolicyEnabled, "ksp-privilege", "", tp.PrivilegeSyscallType{Syscall: c.syscall, HostNamespace: c.hostNamespace, Action: "Audit"})
+
+		matches := tp.MatchPolicies{Policies: []tp.MatchPolicy{secPolicy}}
+		matches.Index = newMatchPolicyIndex(matches.Policies)
+
+		log, _ := matchPolicies(matches, tp.Log{Operation: "Privilege", Source: "/usr/bin/nsenter", Resource: c.resource})
+
+		if matched := log.PolicyName == "ksp-privilege"; matched != c.matched {
+			t.Errorf("[FAIL] %s (hostNamespace: %v) with %s (expected: %v, got: %v)", c.syscall, c.hostNamespace, c.resource, c.matched, matched)
+			return
+		}
+	}
+
+	t.Log("[PASS] Matched setns events joining the namespaces of the host")
+}
+
+func TestMatchNarrowedAllowPolicies(t *testing.T) {
+	fd := &Feeder{}
+
+	allowPolicy := fd.newMatchPolicy(tp.KubeArmorPolicyEnabled, "ksp-allow-args", "", tp.ProcessPathType{
+		Path:      "/usr/bin/python3",
+		MatchArgs: []tp.MatchArgType{{Arg: "app.py"}},
+		Action:    "Allow",
+	})
+
+	if allowPolicy.Resource == "" || allowPolicy.Action != "Allow" {
+		t.Error("[FAIL] Failed to create an allow policy with arguments")
+		return
+	}
+
+	matches := tp.MatchPolicies{Policies: []tp.MatchPolicy{allowPolicy}}
+	matches.Index = newMatchPolicyIndex(matches.Policies)
+
+	cases := []struct {
+		resource string
+		args     []string
+		action   string
+	}{
+		{"/usr/bin/python3 app.py", []string{"app.py"}, ""},
+		{"/usr/bin/python3 -c print(1)", []string{"-c", "print(1)"}, "Audit (Allow)"},
+		{"/usr/bin/perl app.py", []string{"app.py"}, ""},
+	}
+
+	for _, c := range cases {
+		log := getMatchedLog(matchPolicies(matches, tp.Log{
+			ContainerID:   "container-id",
+			PolicyEnabled: tp.KubeArmorPolicyEnabled,
+			Operation:     "Process",
+			Source:        "/bin/bash",
+			Resource:      c.resource,
+			Args:          c.args,
+			Result:        "Passed",
+		}))
+
+		if log.Action != c.action {
+			t.Errorf("[FAIL] %s (expected: '%s', got: '%s')", c.resource, c.action, log.Action)
+			return
+		}
+	}
+
+	t.Log("[PASS] Audited the executions not allowed by the arguments")
+}
+
+func TestMatchFromUserPolicies(t *testing.T) {
+	fd := &Feeder{}
+
+	allowPolicy := fd.newMatchPolicy(tp.KubeArmorPolicyEnabled, "ksp-allow-user", "", tp.FilePathType{
+		Path:     "/etc/shadow",
+		FromUser: tp.MatchUserType{MatchUIDs: []int{0}},
+		Action:   "Allow",
+	})
+
+	blockPolicy := fd.newMatchPolicy(tp.KubeArmorPolicyEnabled, "ksp-block-user", "", tp.ProcessPathType{
+		Path:     "/bin/su",
+		FromUser: tp.MatchUserType{ExcludeUIDs: []int{0}},
+		Action:   "Block",
+	})
+
+	if allowPolicy.Action != "Allow" || blockPolicy.Action != "Audit (Block)" {
+		t.Errorf("[FAIL] Failed to create policies with users (allow: '%s', block: '%s')", allowPolicy.Action, blockPolicy.Action)
+		return
+	}
+
+	matches := tp.MatchPolicies{Policies: []tp.MatchPolicy{allowPolicy, blockPolicy}}
+	matches.Index = newMatchPolicyIndex(matches.Policies)
+
+	cases := []struct {
+		operation string
+		resource  string
+		uid       int32
+		action    string
+	}{
+		{"File", "/etc/shadow", 0, ""},
+		{"File", "/etc/shadow", 1000, "Audit (Allow)"},
+		{"Process", "/bin/su", 0, ""},
+		{"Process", "/bin/su", 1000, "Audit (Block)"},
+	}
+
+	for _, c := range cases {
+		log := getMatchedLog(matchPolicies(matches, tp.Log{
+			ContainerID:   "container-id",
+			PolicyEnabled: tp.KubeArmorPolicyEnabled,
+			UID:           c.uid,
+			Operation:     c.operation,
+			Source:        "/bin/bash",
+			Resource:      c.resource,
+			Result:        "Passed",
+		}))
+
+		if log.Action != c.action {
+			t.Errorf("[FAIL] %s by %d (expected: '%s', got: '%s')", c.resource, c.uid, c.action, log.Action)
+			return
+		}
+	}
+
+	t.Log("[PASS] Audited the operations of the users not allowed or blocked")
+}
+
+func TestMatchArgs(t *testing.T) {
+	fd := &Feeder{}
+
+	cases := []struct {
+		rules   []tp.MatchArgType
+		args    []string
+		matched bool
+	}{
+		{[]tp.MatchArgType{{Arg: "-c"}}, []string{"-c", "print(1)"}, true},
+		{[]tp.MatchArgType{{Arg: "-c"}}, []string{"app.py", "-c"}, true},
+		{[]tp.MatchArgType{{Arg: "-c", Index: 1}}, []string{"-c", "print(1)"}, true},
+		{[]tp.MatchArgType{{Arg: "-c", Index: 1}}, []string{"app.py", "-c"}, false},
+		{[]tp.MatchArgType{{Arg: "-c", Index: 2}}, []string{"app.py", "-c"}, true},
+		{[]tp.MatchArgType{{Arg: "-c", Index: 3}}, []string{"app.py", "-c"}, false},
+		{[]tp.MatchArgType{{Arg: "*.py", Type: "Glob"}}, []string{"-u", "app.py"}, true},
+		{[]tp.MatchArgType{{Arg: "*.py", Type: "Glob"}}, []string{"/app/app.py"}, false},
+		{[]tp.MatchArgType{{Arg: "/app/**.py", Type: "Glob"}}, []string{"/app/src/app.py"}, true},
+		{[]tp.MatchArgType{{Arg: "{-c,-m}", Type: "Glob", Index: 1}}, []string{"-m", "http.server"}, true},
+		{[]tp.MatchArgType{{Arg: "^--(exec|eval)=", Type: "Regexp"}}, []string{"--eval=1"}, true},
+		{[]tp.MatchArgType{{Arg: "-c", Index: 1}, {Arg: "import *", Type: "Glob", Index: 2}}, []string{"-c", "import os"}, true},
+		{[]tp.MatchArgType{{Arg: "-c", Index: 1}, {Arg: "import *", Type: "Glob", Index: 2}}, []string{"-c", "print(1)"}, false},
+	}
+
+	for _, c := range cases {
+		match := tp.MatchPolicy{}
+
+		if !fd.setMatchArgs(&match, c.rules) {
+			t.Errorf("[FAIL] Failed to compile %v", c.rules)
+			return
+		}
+
+		if matched := matchArgs(match, c.args); matched != c.matched {
+			t.Errorf("[FAIL] %v with %v (expected: %v, got: %v)", c.rules, c.args, c.matched, matched)
+			return
+		}
+	}
+
+	t.Log("[PASS] Matched arguments by their positions and patterns")
+}
+
+func TestMatchDirectory(t *testing.T) {
+	cases := []struct {
+		dir       string
+		recursive bool
+		path      string
+		matched   bool
+	}{
+		{"/etc/", false, "/etc/passwd", true},
+		{"/etc/", false, "/etc/ssh/sshd_config", false},
+		{"/etc/", true, "/etc/ssh/sshd_config", true},
+		{"/etc/", false, "/etc/", false},
+		{"/etc/", true, "/etc/", false},
+		{"/etc/", true, "/etc/ssh/", true},
+		{"/etc/", true, "/etcd/config", false},
+	}
+
+	for _, c := range cases {
+		match := tp.MatchPolicy{ResourceType: "Directory", Resource: c.dir, Recursive: c.recursive}
+
+		if matched := matchDirectory(match, c.path); matched != c.matched {
+			t.Errorf("[FAIL] %s (recursive: %v) with %s (expected: %v, got: %v)", c.dir, c.recursive, c.path, c.matched, matched)
+			return
+		}
+
+		// the same as the patterns in AppArmor profiles
+		regexpComp, err := getGlobRegexp(getDirectoryPattern(c.dir, c.recursive))
+		if err != nil || regexpComp.MatchString(c.path) != c.matched {
+			t.Errorf("[FAIL] Different matches of %s (recursive: %v) with %s between the directory and its pattern", c.dir, c.recursive, c.path)
+			return
+		}
+	}
+
+	t.Log("[PASS] Matched directories without the directories themselves")
+}
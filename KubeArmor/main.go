@@ -7,9 +7,11 @@ import (
 	"flag"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/kubearmor/KubeArmor/KubeArmor/core"
 	kg "github.com/kubearmor/KubeArmor/KubeArmor/log"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
 )
 
 func main() {
@@ -38,16 +40,39 @@ func main() {
 	gRPCPtr := flag.String("gRPC", "32767", "gRPC port number")
 	logPathPtr := flag.String("logPath", "none", "log file path, {path|stdout|none}")
 	logFilterPtr := flag.String("logFilter", "policy", "Filter for what kinds of alerts and logs to receive, {policy|system|all}")
+	untrackedNsPtr := flag.String("untrackedNamespaces", "kube-system,kubearmor", "namespaces not to be monitored, comma-separated")
+	policyDiscoveryPathPtr := flag.String("policyDiscoveryPath", "", "directory to write the policies discovered from the observed behavior of pods (disabled if empty)")
 
 	// options (boolean)
 	enableHostPolicyPtr := flag.Bool("enableHostPolicy", false, "enabling host policies")
 	enableEnforcerPerPodPtr := flag.Bool("enableEnforcerPerPod", false, "enabling the enforcer per pod")
+	enableK8sEventsPtr := flag.Bool("enableK8sEvents", false, "enabling Kubernetes events for policy violations")
+	enablePartialSeccompProfilesPtr := flag.Bool("enablePartialSeccompProfiles", false, "enabling seccomp profiles of the syscalls traced in pods (partial, not for enforcement)")
+
+	// options (duration)
+	policyDiscoveryIntervalPtr := flag.Duration("policyDiscoveryInterval", 10*time.Minute, "interval to write the discovered policies")
 
 	flag.Parse()
 
 	// == //
 
-	core.KubeArmor(*clusterPtr, *gRPCPtr, *logPathPtr, *logFilterPtr, *enableHostPolicyPtr, *enableEnforcerPerPodPtr)
+	core.KubeArmor(tp.KubeArmorConfig{
+		ClusterName: *clusterPtr,
+		GRPCPort:    *gRPCPtr,
+		LogPath:     *logPathPtr,
+		LogFilter:   *logFilterPtr,
+
+		UntrackedNamespaces: *untrackedNsPtr,
+
+		EnableHostPolicy:     *enableHostPolicyPtr,
+		EnableEnforcerPerPod: *enableEnforcerPerPodPtr,
+		EnableK8sEvents:      *enableK8sEventsPtr,
+
+		PolicyDiscoveryPath:     *policyDiscoveryPathPtr,
+		PolicyDiscoveryInterval: *policyDiscoveryIntervalPtr,
+
+		EnablePartialSeccompProfiles: *enablePartialSeccompProfilesPtr,
+	})
 
 	// == //
 }
@@ -6,6 +6,7 @@ package monitor
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 // ========== //
@@ -46,6 +47,10 @@ func (mon *SystemMonitor) UpdateHostLogs() {
 				log.Resource = fileName
 				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " flags=" + fileOpenFlags
 
+				if msg.ContextSys.Retval >= 0 {
+					mon.AddFdEntry(msg.ContextSys.HostPID, int32(msg.ContextSys.Retval), log.Operation, log.Resource)
+				}
+
 			case SysOpenAt:
 				var fd string
 				var fileName string
@@ -67,19 +72,67 @@ func (mon *SystemMonitor) UpdateHostLogs() {
 				log.Resource = fileName
 				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " fd=" + fd + " flags=" + fileOpenFlags
 
+				if msg.ContextSys.Retval >= 0 {
+					mon.AddFdEntry(msg.ContextSys.HostPID, int32(msg.ContextSys.Retval), log.Operation, log.Resource)
+				}
+
+			case SysMemfdCreate:
+				var fileName string
+				var memfdFlags string
+
+				if len(msg.ContextArgs) == 2 {
+					if val, ok := msg.ContextArgs[0].(string); ok {
+						fileName = val
+					}
+					if val, ok := msg.ContextArgs[1].(int32); ok {
+						memfdFlags = strconv.Itoa(int(val))
+					}
+				}
+
+				log.Operation = "File"
+				log.Resource = "/memfd:" + fileName
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " flags=" + memfdFlags
+
+				if msg.ContextSys.Retval >= 0 {
+					mon.AddFdEntry(msg.ContextSys.HostPID, int32(msg.ContextSys.Retval), log.Operation, log.Resource)
+				}
+
 			case SysClose:
 				var fd string
+				var fdEntry FdEntry
 
 				if len(msg.ContextArgs) == 1 {
 					if val, ok := msg.ContextArgs[0].(int32); ok {
 						fd = strconv.Itoa(int(val))
+						fdEntry = mon.DeleteFdEntry(msg.ContextSys.HostPID, val)
 					}
 				}
 
-				log.Operation = "File"
-				log.Resource = ""
+				if fdEntry.Operation != "" {
+					log.Operation = fdEntry.Operation
+				} else {
+					log.Operation = "File"
+				}
+
+				log.Resource = fdEntry.Resource
 				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " fd=" + fd
 
+			case SysDup, SysDup2, SysDup3, SysFcntl: // oldfd, (newfd or cmd)
+				// duplicated fds refer to the resources of their original fds (not logged)
+				if len(msg.ContextArgs) >= 1 && msg.ContextSys.Retval >= 0 {
+					if val, ok := msg.ContextArgs[0].(int32); ok {
+						mon.DuplicateFdEntry(msg.ContextSys.HostPID, val, int32(msg.ContextSys.Retval))
+					}
+				}
+
+				continue
+
+			case DoFork: // the host pid of the child in retval
+				// forked processes inherit the fds of their parents (not logged)
+				mon.CopyFdTable(msg.ContextSys.HostPID, uint32(msg.ContextSys.Retval))
+
+				continue
+
 			case SysSocket: // domain, type, proto
 				var sockDomain string
 				var sockType string
@@ -101,13 +154,19 @@ func (mon *SystemMonitor) UpdateHostLogs() {
 				log.Resource = "domain=" + sockDomain + " type=" + sockType + " protocol=" + sockProtocol
 				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID))
 
+				if msg.ContextSys.Retval >= 0 {
+					mon.AddFdEntry(msg.ContextSys.HostPID, int32(msg.ContextSys.Retval), log.Operation, log.Resource)
+				}
+
 			case SysConnect: // fd, sockaddr
 				var fd string
+				var sockFd int32 = -1
 				var sockAddr map[string]string
 
 				if len(msg.ContextArgs) == 2 {
 					if val, ok := msg.ContextArgs[0].(int32); ok {
 						fd = strconv.Itoa(int(val))
+						sockFd = val
 					}
 					if val, ok := msg.ContextArgs[1].(map[string]string); ok {
 						sockAddr = val
@@ -125,15 +184,23 @@ func (mon *SystemMonitor) UpdateHostLogs() {
 					}
 				}
 
+				if log.Resource != "" {
+					mon.AddFdEntry(msg.ContextSys.HostPID, sockFd, log.Operation, log.Resource)
+				} else {
+					log.Resource = mon.GetFdEntry(msg.ContextSys.HostPID, sockFd).Resource
+				}
+
 				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " fd=" + fd
 
 			case SysAccept: // fd, sockaddr
 				var fd string
+				var sockFd int32 = -1
 				var sockAddr map[string]string
 
 				if len(msg.ContextArgs) == 2 {
 					if val, ok := msg.ContextArgs[0].(int32); ok {
 						fd = strconv.Itoa(int(val))
+						sockFd = val
 					}
 					if val, ok := msg.ContextArgs[1].(map[string]string); ok {
 						sockAddr = val
@@ -152,13 +219,23 @@ func (mon *SystemMonitor) UpdateHostLogs() {
 					}
 				}
 
+				if log.Resource == "" {
+					log.Resource = mon.GetFdEntry(msg.ContextSys.HostPID, sockFd).Resource
+				}
+
+				if msg.ContextSys.Retval >= 0 {
+					mon.AddFdEntry(msg.ContextSys.HostPID, int32(msg.ContextSys.Retval), log.Operation, log.Resource)
+				}
+
 			case SysBind: // fd, sockaddr
 				var fd string
+				var sockFd int32 = -1
 				var sockAddr map[string]string
 
 				if len(msg.ContextArgs) == 2 {
 					if val, ok := msg.ContextArgs[0].(int32); ok {
 						fd = strconv.Itoa(int(val))
+						sockFd = val
 					}
 					if val, ok := msg.ContextArgs[1].(map[string]string); ok {
 						sockAddr = val
@@ -176,6 +253,12 @@ func (mon *SystemMonitor) UpdateHostLogs() {
 					}
 				}
 
+				if log.Resource != "" {
+					mon.AddFdEntry(msg.ContextSys.HostPID, sockFd, log.Operation, log.Resource)
+				} else {
+					log.Resource = mon.GetFdEntry(msg.ContextSys.HostPID, sockFd).Resource
+				}
+
 				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " fd=" + fd
 
 			case SysListen: // fd
@@ -184,13 +267,304 @@ func (mon *SystemMonitor) UpdateHostLogs() {
 				if len(msg.ContextArgs) == 2 {
 					if val, ok := msg.ContextArgs[0].(int32); ok {
 						fd = strconv.Itoa(int(val))
+						log.Resource = mon.GetFdEntry(msg.ContextSys.HostPID, val).Resource
 					}
 				}
 
 				log.Operation = "Network"
-				log.Resource = ""
 				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " fd=" + fd
 
+			case SysSendTo, SysRecvFrom, SysSendMsg, SysRecvMsg, SysSendMMsg: // fd, dns, (sockaddr)
+				var fd string
+				var sockFd int32 = -1
+				var dnsMsg DNSMessage
+				var sockAddr map[string]string
+
+				if len(msg.ContextArgs) >= 2 {
+					if val, ok := msg.ContextArgs[0].(int32); ok {
+						fd = strconv.Itoa(int(val))
+						sockFd = val
+					}
+					if val, ok := msg.ContextArgs[1].(DNSMessage); ok {
+						dnsMsg = val
+					}
+				}
+
+				if len(msg.ContextArgs) == 3 {
+					if val, ok := msg.ContextArgs[2].(map[string]string); ok {
+						sockAddr = val
+					}
+				}
+
+				if dnsMsg.Domain == "" {
+					continue
+				}
+
+				log.Operation = "Network"
+				log.Resource = "domain=" + dnsMsg.Domain
+
+				if dnsMsg.Response {
+					log.Resource = log.Resource + " answers=" + strings.Join(dnsMsg.Answers, ",")
+				}
+
+				server := ""
+
+				for k, v := range sockAddr {
+					if server == "" {
+						server = k + "=" + v
+					} else {
+						server = server + " " + k + "=" + v
+					}
+				}
+
+				if server == "" {
+					server = mon.GetFdEntry(msg.ContextSys.HostPID, sockFd).Resource
+				}
+
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " fd=" + fd
+
+				if dnsMsg.Response {
+					log.Data = log.Data + " dns=response qtype=" + dnsMsg.QType
+				} else {
+					log.Data = log.Data + " dns=query qtype=" + dnsMsg.QType
+				}
+
+				if server != "" {
+					log.Data = log.Data + " " + server
+				}
+
+			case SysInitModule: // len, params
+				var size string
+				var params string
+
+				if len(msg.ContextArgs) == 2 {
+					if val, ok := msg.ContextArgs[0].(int32); ok {
+						size = strconv.Itoa(int(val))
+					}
+					if val, ok := msg.ContextArgs[1].(string); ok {
+						params = val
+					}
+				}
+
+				log.Operation = "Kernel"
+				log.Resource = "operation=ModuleLoad"
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " size=" + size + " params=" + params
+
+			case SysFinitModule: // fd, params, flags
+				var fd string
+				var module string
+				var params string
+				var flags string
+
+				if len(msg.ContextArgs) == 3 {
+					if val, ok := msg.ContextArgs[0].(int32); ok {
+						fd = strconv.Itoa(int(val))
+						module = mon.GetFdEntry(msg.ContextSys.HostPID, val).Resource
+					}
+					if val, ok := msg.ContextArgs[1].(string); ok {
+						params = val
+					}
+					if val, ok := msg.ContextArgs[2].(int32); ok {
+						flags = strconv.Itoa(int(val))
+					}
+				}
+
+				log.Operation = "Kernel"
+				log.Resource = "operation=ModuleLoad"
+
+				if module != "" {
+					log.Resource = log.Resource + " module=" + module
+				}
+
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " fd=" + fd + " params=" + params + " flags=" + flags
+
+			case SysDeleteModule: // name, flags
+				var module string
+				var flags string
+
+				if len(msg.ContextArgs) == 2 {
+					if val, ok := msg.ContextArgs[0].(string); ok {
+						module = val
+					}
+					if val, ok := msg.ContextArgs[1].(int32); ok {
+						flags = strconv.Itoa(int(val))
+					}
+				}
+
+				log.Operation = "Kernel"
+				log.Resource = "operation=ModuleUnload module=" + module
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " flags=" + flags
+
+			case SysBPF: // prog_type, prog_name
+				var progType string
+				var progName string
+
+				if len(msg.ContextArgs) == 2 {
+					if val, ok := msg.ContextArgs[0].(int32); ok {
+						progType = getBPFProgType(uint32(val))
+					}
+					if val, ok := msg.ContextArgs[1].(string); ok {
+						progName = val
+					}
+				}
+
+				log.Operation = "Kernel"
+				log.Resource = "operation=BPFProgLoad prog_type=" + progType
+
+				if progName != "" {
+					log.Resource = log.Resource + " prog_name=" + progName
+				}
+
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " cmd=BPF_PROG_LOAD"
+
+			case SysSetUID, SysSetGID: // uid or gid
+				var id string
+
+				if len(msg.ContextArgs) == 1 {
+					if val, ok := msg.ContextArgs[0].(int32); ok {
+						id = strconv.Itoa(int(val))
+					}
+				}
+
+				log.Operation = "Privilege"
+
+				if msg.ContextSys.EventID == SysSetUID {
+					log.Resource = "syscall=setuid uid=" + id
+				} else {
+					log.Resource = "syscall=setgid gid=" + id
+				}
+
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID))
+
+			case SysSetReUID, SysSetReGID: // real id, effective id
+				var rid string
+				var eid string
+
+				if len(msg.ContextArgs) == 2 {
+					if val, ok := msg.ContextArgs[0].(int32); ok {
+						rid = strconv.Itoa(int(val))
+					}
+					if val, ok := msg.ContextArgs[1].(int32); ok {
+						eid = strconv.Itoa(int(val))
+					}
+				}
+
+				log.Operation = "Privilege"
+
+				if msg.ContextSys.EventID == SysSetReUID {
+					log.Resource = "syscall=setreuid ruid=" + rid + " euid=" + eid
+				} else {
+					log.Resource = "syscall=setregid rgid=" + rid + " egid=" + eid
+				}
+
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID))
+
+			case SysSetResUID, SysSetResGID: // real id, effective id, saved id
+				var rid string
+				var eid string
+				var sid string
+
+				if len(msg.ContextArgs) == 3 {
+					if val, ok := msg.ContextArgs[0].(int32); ok {
+						rid = strconv.Itoa(int(val))
+					}
+					if val, ok := msg.ContextArgs[1].(int32); ok {
+						eid = strconv.Itoa(int(val))
+					}
+					if val, ok := msg.ContextArgs[2].(int32); ok {
+						sid = strconv.Itoa(int(val))
+					}
+				}
+
+				log.Operation = "Privilege"
+
+				if msg.ContextSys.EventID == SysSetResUID {
+					log.Resource = "syscall=setresuid ruid=" + rid + " euid=" + eid + " suid=" + sid
+				} else {
+					log.Resource = "syscall=setresgid rgid=" + rid + " egid=" + eid + " sgid=" + sid
+				}
+
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID))
+
+			case SysSetNS: // fd, nstype
+				var fd string
+				var nsPath string
+				var nsType string
+
+				if len(msg.ContextArgs) == 2 {
+					if val, ok := msg.ContextArgs[0].(int32); ok {
+						fd = strconv.Itoa(int(val))
+						nsPath = mon.GetFdEntry(msg.ContextSys.HostPID, val).Resource
+					}
+					if val, ok := msg.ContextArgs[1].(string); ok {
+						nsType = val
+					}
+				}
+
+				log.Operation = "Privilege"
+				log.Resource = "syscall=setns nstype=" + nsType
+
+				if nsPath != "" {
+					log.Resource = log.Resource + " path=" + nsPath
+				}
+
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " fd=" + fd
+
+			case SysUnshare: // flags
+				var flags string
+
+				if len(msg.ContextArgs) == 1 {
+					if val, ok := msg.ContextArgs[0].(string); ok {
+						flags = val
+					}
+				}
+
+				log.Operation = "Privilege"
+				log.Resource = "syscall=unshare flags=" + flags
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID))
+
+			case SysMount: // source, target, fstype, flags
+				var source string
+				var target string
+				var fsType string
+				var flags string
+
+				if len(msg.ContextArgs) == 4 {
+					if val, ok := msg.ContextArgs[0].(string); ok {
+						source = val
+					}
+					if val, ok := msg.ContextArgs[1].(string); ok {
+						target = val
+					}
+					if val, ok := msg.ContextArgs[2].(string); ok {
+						fsType = val
+					}
+					if val, ok := msg.ContextArgs[3].(string); ok {
+						flags = val
+					}
+				}
+
+				log.Operation = "Privilege"
+				log.Resource = "syscall=mount source=" + source + " target=" + target + " fstype=" + fsType
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " flags=" + flags
+
+			case SysPtrace: // request, pid
+				var request string
+				var pid string
+
+				if len(msg.ContextArgs) == 2 {
+					if val, ok := msg.ContextArgs[0].(string); ok {
+						request = val
+					}
+					if val, ok := msg.ContextArgs[1].(int32); ok {
+						pid = strconv.Itoa(int(val))
+					}
+				}
+
+				log.Operation = "Privilege"
+				log.Resource = "syscall=ptrace request=" + request + " pid=" + pid
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID))
+
 			default:
 				continue
 			}
@@ -30,6 +30,30 @@ func (mon *SystemMonitor) AddActiveHostPid(hostPid uint32, node tp.PidNode) {
 	}
 }
 
+// SeedActiveHostPids Function
+func (mon *SystemMonitor) SeedActiveHostPids() {
+	// the daemon runs with hostPID, so /proc/1 is the init process of the host
+	hostPidNS, _, err := GetProcNsIDs(1)
+	if err != nil {
+		return
+	}
+
+	for _, hostPid := range GetProcHostPids() {
+		if procPidNS, _, err := GetProcNsIDs(hostPid); err != nil || procPidNS != hostPidNS {
+			continue
+		}
+
+		// keep the nodes built from execve
+		if mon.GetHostExecPath(hostPid) != "" {
+			continue
+		}
+
+		if node, err := mon.BuildPidNodeFromProc(hostPid); err == nil {
+			mon.AddActiveHostPid(hostPid, node)
+		}
+	}
+}
+
 // GetHostExecPath Function
 func (mon *SystemMonitor) GetHostExecPath(hostPid uint32) string {
 	ActiveHostMap := *(mon.ActiveHostMap)
@@ -60,6 +84,10 @@ func (mon *SystemMonitor) DeleteActiveHostPid(hostPid uint32) {
 		if node, ok := pidMap[hostPid]; ok {
 			node.Exited = true
 			node.ExitedTime = time.Now()
+			pidMap[hostPid] = node
 		}
 	}
+
+	// clear the fd table of the exited process
+	mon.DeleteFdTable(hostPid)
 }
@@ -4,8 +4,15 @@
 package monitor
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
+	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
 	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
 )
 
@@ -14,26 +21,66 @@ import (
 // ============================ //
 
 // LookupContainerID Function
-func (mon *SystemMonitor) LookupContainerID(pidns, mntns, ppid, pid uint32) string {
+func (mon *SystemMonitor) LookupContainerID(cgroupID uint64, pidns, mntns, ppid, pid uint32) string {
 	key := NsKey{PidNS: pidns, MntNS: mntns}
 
 	mon.NsMapLock.RLock()
 	defer mon.NsMapLock.RUnlock()
 
+	// cgroup ids still work for containers sharing the host pid namespace or a pod's pid namespace
+	if cgroupID != 0 {
+		if val, ok := mon.CgroupMap[cgroupID]; ok {
+			return val
+		}
+	}
+
+	if pidns == 0 || mntns == 0 {
+		return ""
+	}
+
 	if val, ok := mon.NsMap[key]; ok {
 		return val
 	}
 
+	// processes that joined other namespaces (e.g., setns) are still found by their host pids
+	if _, ok := mon.IgnoredNsMap[key]; !ok && mon.ActiveHostPidMap != nil {
+		ActivePidMapLock := *(mon.ActivePidMapLock)
+
+		ActivePidMapLock.RLock()
+		defer ActivePidMapLock.RUnlock()
+
+		for containerID, pidMap := range *mon.ActiveHostPidMap {
+			if _, ok := pidMap[pid]; ok {
+				return containerID
+			}
+		}
+	}
+
 	return ""
 }
 
 // AddContainerIDToNsMap Function
-func (mon *SystemMonitor) AddContainerIDToNsMap(containerID string, pidns, mntns uint32) {
+func (mon *SystemMonitor) AddContainerIDToNsMap(containerID, namespaceName string, cgroupID uint64, pidns, mntns uint32) {
 	key := NsKey{PidNS: pidns, MntNS: mntns}
 
 	mon.NsMapLock.Lock()
 	defer mon.NsMapLock.Unlock()
 
+	if kl.ContainsElement(mon.UntrackedNamespaces, namespaceName) {
+		mon.IgnoredNsMap[key] = containerID
+		mon.UpdateNsIgnoreMap(key, true)
+		return
+	}
+
+	if cgroupID != 0 {
+		mon.CgroupMap[cgroupID] = containerID
+		mon.UpdateCgroupIDMap(cgroupID, true)
+	} else if mon.CgroupFallbackOnce != nil {
+		mon.CgroupFallbackOnce.Do(func() {
+			mon.Logger.Printf("Failed to get the cgroup id of a container (%s), falling back to pid and mnt namespaces", containerID)
+		})
+	}
+
 	mon.NsMap[key] = containerID
 }
 
@@ -41,8 +88,16 @@ func (mon *SystemMonitor) AddContainerIDToNsMap(containerID string, pidns, mntns
 func (mon *SystemMonitor) DeleteContainerIDFromNsMap(containerID string) {
 	ns := NsKey{}
 
-	mon.NsMapLock.RLock()
-	defer mon.NsMapLock.RUnlock()
+	mon.NsMapLock.Lock()
+	defer mon.NsMapLock.Unlock()
+
+	for cgroupID, val := range mon.CgroupMap {
+		if containerID == val {
+			delete(mon.CgroupMap, cgroupID)
+			mon.UpdateCgroupIDMap(cgroupID, false)
+			break
+		}
+	}
 
 	for key, val := range mon.NsMap {
 		if containerID == val {
@@ -53,7 +108,167 @@ func (mon *SystemMonitor) DeleteContainerIDFromNsMap(containerID string) {
 
 	if ns.PidNS != 0 && ns.MntNS != 0 {
 		delete(mon.NsMap, ns)
+		return
+	}
+
+	for key, val := range mon.IgnoredNsMap {
+		if containerID == val {
+			ns = key
+			break
+		}
+	}
+
+	if ns.PidNS != 0 && ns.MntNS != 0 {
+		delete(mon.IgnoredNsMap, ns)
+		mon.UpdateNsIgnoreMap(ns, false)
+	}
+}
+
+// UpdateNsIgnoreMap Function
+func (mon *SystemMonitor) UpdateNsIgnoreMap(key NsKey, ignore bool) {
+	if mon.NsIgnoreMap == nil {
+		return
+	}
+
+	// struct ns_key { u32 pid_ns; u32 mnt_ns; }
+	nsKey := make([]byte, 8)
+	mon.HostByteOrder.PutUint32(nsKey[0:4], key.PidNS)
+	mon.HostByteOrder.PutUint32(nsKey[4:8], key.MntNS)
+
+	if ignore {
+		leaf := make([]byte, 4)
+		mon.HostByteOrder.PutUint32(leaf, 1)
+
+		if err := mon.NsIgnoreMap.Set(nsKey, leaf); err != nil {
+			mon.Logger.Errf("Failed to add a namespace to ns_ignore_map (%d/%d, %s)", key.PidNS, key.MntNS, err.Error())
+		}
+	} else {
+		if err := mon.NsIgnoreMap.Delete(nsKey); err != nil {
+			mon.Logger.Errf("Failed to remove a namespace from ns_ignore_map (%d/%d, %s)", key.PidNS, key.MntNS, err.Error())
+		}
+	}
+}
+
+// UpdateCgroupIDMap Function
+func (mon *SystemMonitor) UpdateCgroupIDMap(cgroupID uint64, track bool) {
+	if mon.CgroupIDMap == nil {
+		return
 	}
+
+	// u64 cgroup id
+	key := make([]byte, 8)
+	mon.HostByteOrder.PutUint64(key, cgroupID)
+
+	if track {
+		leaf := make([]byte, 4)
+		mon.HostByteOrder.PutUint32(leaf, 1)
+
+		if err := mon.CgroupIDMap.Set(key, leaf); err != nil {
+			mon.Logger.Errf("Failed to add a cgroup id to cgroup_map (%d, %s)", cgroupID, err.Error())
+		}
+	} else {
+		if err := mon.CgroupIDMap.Delete(key); err != nil {
+			mon.Logger.Errf("Failed to remove a cgroup id from cgroup_map (%d, %s)", cgroupID, err.Error())
+		}
+	}
+}
+
+// =========================== //
+// == File Descriptor Table == //
+// =========================== //
+
+// AddFdEntry Function
+func (mon *SystemMonitor) AddFdEntry(hostPid uint32, fd int32, operation, resource string) {
+	if fd < 0 {
+		return
+	}
+
+	mon.FdMapLock.Lock()
+	defer mon.FdMapLock.Unlock()
+
+	if fdMap, ok := mon.FdMap[hostPid]; ok {
+		fdMap[fd] = FdEntry{Operation: operation, Resource: resource}
+	} else {
+		mon.FdMap[hostPid] = map[int32]FdEntry{fd: {Operation: operation, Resource: resource}}
+	}
+}
+
+// GetFdEntry Function
+func (mon *SystemMonitor) GetFdEntry(hostPid uint32, fd int32) FdEntry {
+	mon.FdMapLock.RLock()
+	defer mon.FdMapLock.RUnlock()
+
+	if fdMap, ok := mon.FdMap[hostPid]; ok {
+		if entry, ok := fdMap[fd]; ok {
+			return entry
+		}
+	}
+
+	return FdEntry{}
+}
+
+// DeleteFdEntry Function
+func (mon *SystemMonitor) DeleteFdEntry(hostPid uint32, fd int32) FdEntry {
+	mon.FdMapLock.Lock()
+	defer mon.FdMapLock.Unlock()
+
+	if fdMap, ok := mon.FdMap[hostPid]; ok {
+		if entry, ok := fdMap[fd]; ok {
+			delete(fdMap, fd)
+			return entry
+		}
+	}
+
+	return FdEntry{}
+}
+
+// DeleteFdTable Function
+func (mon *SystemMonitor) DeleteFdTable(hostPid uint32) {
+	mon.FdMapLock.Lock()
+	defer mon.FdMapLock.Unlock()
+
+	delete(mon.FdMap, hostPid)
+}
+
+// DuplicateFdEntry Function
+func (mon *SystemMonitor) DuplicateFdEntry(hostPid uint32, oldFd, newFd int32) {
+	if newFd < 0 || oldFd == newFd {
+		return
+	}
+
+	mon.FdMapLock.Lock()
+	defer mon.FdMapLock.Unlock()
+
+	fdMap, ok := mon.FdMap[hostPid]
+	if !ok {
+		return
+	}
+
+	// dup2 and dup3 close newFd first, so an unknown oldFd leaves nothing behind
+	if entry, ok := fdMap[oldFd]; ok {
+		fdMap[newFd] = entry
+	} else {
+		delete(fdMap, newFd)
+	}
+}
+
+// CopyFdTable Function
+func (mon *SystemMonitor) CopyFdTable(parentHostPid, childHostPid uint32) {
+	mon.FdMapLock.Lock()
+	defer mon.FdMapLock.Unlock()
+
+	fdMap, ok := mon.FdMap[parentHostPid]
+	if !ok {
+		return
+	}
+
+	// a forked process inherits the open fds of its parent
+	childFdMap := make(map[int32]FdEntry, len(fdMap))
+	for fd, entry := range fdMap {
+		childFdMap[fd] = entry
+	}
+
+	mon.FdMap[childHostPid] = childFdMap
 }
 
 // ================== //
@@ -73,12 +288,14 @@ func (mon *SystemMonitor) BuildPidNode(ctx SyscallContext, execPath string, args
 
 	node.Comm = string(ctx.Comm[:])
 	node.ExecPath = execPath
+	node.Args = []string{}
 
 	for idx, arg := range args {
 		if idx == 0 {
 			continue
 		} else {
 			node.ExecPath = node.ExecPath + " " + arg
+			node.Args = append(node.Args, arg)
 		}
 	}
 
@@ -87,6 +304,210 @@ func (mon *SystemMonitor) BuildPidNode(ctx SyscallContext, execPath string, args
 	return node
 }
 
+// ============================= //
+// == Process Tree from /proc == //
+// ============================= //
+
+// procRoot is where procfs is mounted (KubeArmor runs with hostPID, so it shows the processes of the host)
+var procRoot = "/proc"
+
+// GetProcNsIDs Function
+func GetProcNsIDs(hostPid uint32) (uint32, uint32, error) {
+	procPath := filepath.Join(procRoot, strconv.FormatUint(uint64(hostPid), 10))
+
+	pidns := uint32(0)
+	mntns := uint32(0)
+
+	if data, err := os.Readlink(filepath.Join(procPath, "ns", "pid")); err == nil {
+		if _, err := fmt.Sscanf(data, "pid:[%d]", &pidns); err != nil {
+			return 0, 0, err
+		}
+	} else {
+		return 0, 0, err
+	}
+
+	if data, err := os.Readlink(filepath.Join(procPath, "ns", "mnt")); err == nil {
+		if _, err := fmt.Sscanf(data, "mnt:[%d]", &mntns); err != nil {
+			return 0, 0, err
+		}
+	} else {
+		return 0, 0, err
+	}
+
+	return pidns, mntns, nil
+}
+
+// GetProcHostPids Function
+func GetProcHostPids() []uint32 {
+	hostPids := []uint32{}
+
+	files, err := ioutil.ReadDir(procRoot)
+	if err != nil {
+		return hostPids
+	}
+
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+
+		if hostPid, err := strconv.ParseUint(file.Name(), 10, 32); err == nil {
+			hostPids = append(hostPids, uint32(hostPid))
+		}
+	}
+
+	return hostPids
+}
+
+// GetCgroupHostPids Function
+func GetCgroupHostPids(cgroupDir string) []uint32 {
+	hostPids := []uint32{}
+
+	// cgroup.procs lists the pids of the processes in the cgroup (not in its children)
+	data, err := ioutil.ReadFile(filepath.Join(cgroupDir, "cgroup.procs"))
+	if err != nil {
+		return hostPids
+	}
+
+	for _, val := range strings.Fields(string(data)) {
+		if hostPid, err := strconv.ParseUint(val, 10, 32); err == nil {
+			hostPids = append(hostPids, uint32(hostPid))
+		}
+	}
+
+	return hostPids
+}
+
+// readProcStatus Function
+func readProcStatus(hostPid uint32) (map[string][]string, error) {
+	status := map[string][]string{}
+
+	data, err := ioutil.ReadFile(filepath.Join(procRoot, strconv.FormatUint(uint64(hostPid), 10), "status"))
+	if err != nil {
+		return status, err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		status[strings.TrimSuffix(fields[0], ":")] = fields[1:]
+	}
+
+	return status, nil
+}
+
+// BuildPidNodeFromProc Function
+func (mon *SystemMonitor) BuildPidNodeFromProc(hostPid uint32) (tp.PidNode, error) {
+	node := tp.PidNode{}
+
+	procPath := filepath.Join(procRoot, strconv.FormatUint(uint64(hostPid), 10))
+
+	status, err := readProcStatus(hostPid)
+	if err != nil {
+		return node, err
+	}
+
+	// kernel threads do not have any executables
+	execPath, err := os.Readlink(filepath.Join(procPath, "exe"))
+	if err != nil {
+		return node, err
+	}
+
+	node.HostPID = hostPid
+	node.PID = hostPid
+
+	if val, ok := status["PPid"]; ok {
+		if ppid, err := strconv.ParseUint(val[0], 10, 32); err == nil {
+			node.HostPPID = uint32(ppid)
+			node.PPID = uint32(ppid)
+		}
+	}
+
+	if val, ok := status["Uid"]; ok {
+		if uid, err := strconv.ParseUint(val[0], 10, 32); err == nil {
+			node.UID = uint32(uid)
+		}
+	}
+
+	if val, ok := status["Name"]; ok {
+		node.Comm = val[0]
+	}
+
+	// NSpid lists the pids from the host pid namespace to the innermost one
+	if nspid, ok := status["NSpid"]; ok && len(nspid) > 1 {
+		if pid, err := strconv.ParseUint(nspid[len(nspid)-1], 10, 32); err == nil {
+			node.PID = uint32(pid)
+		}
+
+		node.PPID = 0
+
+		// the parent is in the same pid namespace only if it has the same depth
+		if parentStatus, err := readProcStatus(node.HostPPID); err == nil {
+			if parentNSpid, ok := parentStatus["NSpid"]; ok && len(parentNSpid) == len(nspid) {
+				if ppid, err := strconv.ParseUint(parentNSpid[len(parentNSpid)-1], 10, 32); err == nil {
+					node.PPID = uint32(ppid)
+				}
+			}
+		}
+	}
+
+	node.ExecPath = strings.TrimSuffix(execPath, " (deleted)")
+	node.Args = []string{}
+
+	if cmdline, err := ioutil.ReadFile(filepath.Join(procPath, "cmdline")); err == nil {
+		args := strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")
+		for idx, arg := range args {
+			if idx == 0 {
+				continue
+			} else {
+				node.ExecPath = node.ExecPath + " " + arg
+				node.Args = append(node.Args, arg)
+			}
+		}
+	}
+
+	node.Exited = false
+
+	return node, nil
+}
+
+// SeedActivePids Function
+func (mon *SystemMonitor) SeedActivePids(containerID, cgroupDir string, pidns, mntns uint32) {
+	if pidns == 0 || mntns == 0 {
+		return
+	}
+
+	// only the processes in the cgroup of the container are checked,
+	// and all the processes are checked only without the unified hierarchy (cgroup v1)
+	var hostPids []uint32
+	if cgroupDir != "" {
+		hostPids = GetCgroupHostPids(cgroupDir)
+	} else {
+		hostPids = GetProcHostPids()
+	}
+
+	for _, hostPid := range hostPids {
+		if procPidNS, procMntNS, err := GetProcNsIDs(hostPid); err != nil || procPidNS != pidns || procMntNS != mntns {
+			continue
+		}
+
+		// keep the nodes built from execve
+		if mon.GetExecPathWithHostPID(containerID, hostPid) != "" {
+			continue
+		}
+
+		if node, err := mon.BuildPidNodeFromProc(hostPid); err == nil {
+			node.PidID = pidns
+			node.MntID = mntns
+
+			mon.AddActivePid(containerID, node)
+		}
+	}
+}
+
 // AddActivePid Function
 func (mon *SystemMonitor) AddActivePid(containerID string, node tp.PidNode) {
 	ActivePidMap := *(mon.ActivePidMap)
@@ -161,6 +582,7 @@ func (mon *SystemMonitor) DeleteActivePid(containerID string, ctx SyscallContext
 		if node, ok := pidMap[ctx.PID]; ok {
 			node.Exited = true
 			node.ExitedTime = time.Now()
+			pidMap[ctx.PID] = node
 		}
 	}
 
@@ -171,8 +593,12 @@ func (mon *SystemMonitor) DeleteActivePid(containerID string, ctx SyscallContext
 		if node, ok := pidMap[ctx.HostPID]; ok {
 			node.Exited = true
 			node.ExitedTime = time.Now()
+			pidMap[ctx.HostPID] = node
 		}
 	}
+
+	// clear the fd table of the exited process
+	mon.DeleteFdTable(ctx.HostPID)
 }
 
 // CleanUpExitedHostPids Function
@@ -0,0 +1,399 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package monitor
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	fd "github.com/kubearmor/KubeArmor/KubeArmor/feeder"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+func TestLookupContainerID(t *testing.T) {
+	// Set up Test Data
+
+	// containers
+	Containers := map[string]tp.Container{}
+	ContainersLock := new(sync.RWMutex)
+
+	// container id -> (host) pid
+	ActivePidMap := map[string]tp.PidMap{}
+	ActiveHostPidMap := map[string]tp.PidMap{}
+	ActivePidMapLock := new(sync.RWMutex)
+
+	// host pid
+	ActiveHostMap := map[uint32]tp.PidMap{}
+	ActiveHostMapLock := new(sync.RWMutex)
+
+	// Create Feeder
+	Logger := fd.NewFeeder("Default", "32767", "none", "policy", false)
+	if Logger == nil {
+		t.Log("[FAIL] Failed to create Feeder")
+		return
+	}
+	defer Logger.DestroyFeeder()
+
+	// Create System Monitor
+
+	systemMonitor := NewSystemMonitor(Logger, false, &Containers, &ContainersLock,
+		&ActivePidMap, &ActiveHostPidMap, &ActivePidMapLock, &ActiveHostMap, &ActiveHostMapLock)
+	if systemMonitor == nil {
+		t.Log("[FAIL] Failed to create SystemMonitor")
+		return
+	}
+
+	// the pid namespace of the host (PROC_PID_INIT_INO)
+	hostPidNS := uint32(4026531836)
+
+	// a container with hostPID and a container with its own pid namespace (no cgroup id)
+	systemMonitor.AddContainerIDToNsMap("hostpid-container", "multiubuntu", 1234, hostPidNS, 4026532001)
+	systemMonitor.AddContainerIDToNsMap("ns-container", "multiubuntu", 0, 4026532100, 4026532101)
+
+	// events from the host pid namespace (container mode)
+	if containerID := systemMonitor.LookupContainerID(1234, hostPidNS, 4026532001, 1, 100); containerID != "hostpid-container" {
+		t.Errorf("[FAIL] Failed to attribute an event of a hostPID container (%s)", containerID)
+		return
+	}
+
+	// events without namespaces (host and container mode)
+	if containerID := systemMonitor.LookupContainerID(1234, 0, 0, 1, 100); containerID != "hostpid-container" {
+		t.Errorf("[FAIL] Failed to attribute an event of a hostPID container without namespaces (%s)", containerID)
+		return
+	}
+
+	t.Log("[PASS] Attributed the events of a hostPID container by its cgroup id")
+
+	if containerID := systemMonitor.LookupContainerID(0, 4026532100, 4026532101, 1, 200); containerID != "ns-container" {
+		t.Errorf("[FAIL] Failed to attribute an event by namespaces (%s)", containerID)
+		return
+	}
+
+	if containerID := systemMonitor.LookupContainerID(5678, hostPidNS, 4026531840, 1, 300); containerID != "" {
+		t.Errorf("[FAIL] Attributed an event of the host to a container (%s)", containerID)
+		return
+	}
+
+	t.Log("[PASS] Attributed the events of other containers by namespaces")
+
+	systemMonitor.DeleteContainerIDFromNsMap("hostpid-container")
+
+	if containerID := systemMonitor.LookupContainerID(1234, hostPidNS, 4026532001, 1, 100); containerID != "" {
+		t.Errorf("[FAIL] Attributed an event to a deleted container (%s)", containerID)
+		return
+	}
+
+	t.Log("[PASS] Deleted the cgroup id of a container")
+}
+
+func TestDeleteActivePid(t *testing.T) {
+	// Set up Test Data
+
+	ActivePidMap := map[string]tp.PidMap{"container": {100: tp.PidNode{HostPID: 1100, PID: 100}}}
+	ActiveHostPidMap := map[string]tp.PidMap{"container": {1100: tp.PidNode{HostPID: 1100, PID: 100}}}
+	ActivePidMapLock := new(sync.RWMutex)
+
+	systemMonitor := &SystemMonitor{
+		ActivePidMap:     &ActivePidMap,
+		ActiveHostPidMap: &ActiveHostPidMap,
+		ActivePidMapLock: &ActivePidMapLock,
+		FdMap:            map[uint32]map[int32]FdEntry{},
+		FdMapLock:        new(sync.RWMutex),
+	}
+
+	// mark the process as exited (pid nodes are values, so they should be stored back)
+	systemMonitor.DeleteActivePid("container", SyscallContext{HostPID: 1100, PID: 100})
+
+	if node := ActivePidMap["container"][100]; !node.Exited || node.ExitedTime.IsZero() {
+		t.Error("[FAIL] Failed to mark the pid as exited")
+		return
+	}
+
+	if node := ActiveHostPidMap["container"][1100]; !node.Exited || node.ExitedTime.IsZero() {
+		t.Error("[FAIL] Failed to mark the host pid as exited")
+		return
+	}
+
+	t.Log("[PASS] Marked the process as exited")
+}
+
+func TestFdTable(t *testing.T) {
+	// Set up Test Data
+
+	systemMonitor := &SystemMonitor{
+		FdMap:     map[uint32]map[int32]FdEntry{},
+		FdMapLock: new(sync.RWMutex),
+	}
+
+	systemMonitor.AddFdEntry(1100, 3, "File", "/etc/passwd")
+	systemMonitor.AddFdEntry(1100, 4, "Network", "domain=AF_INET type=SOCK_STREAM protocol=0")
+	systemMonitor.AddFdEntry(1100, -1, "File", "/etc/shadow")
+
+	if entry := systemMonitor.GetFdEntry(1100, 3); entry.Operation != "File" || entry.Resource != "/etc/passwd" {
+		t.Errorf("[FAIL] Failed to get an fd entry (%v)", entry)
+		return
+	}
+
+	if entry := systemMonitor.GetFdEntry(1100, -1); entry.Resource != "" {
+		t.Errorf("[FAIL] Added an fd entry of a failed syscall (%v)", entry)
+		return
+	}
+
+	if entry := systemMonitor.GetFdEntry(1200, 3); entry.Resource != "" {
+		t.Errorf("[FAIL] Got an fd entry of another process (%v)", entry)
+		return
+	}
+
+	t.Log("[PASS] Added and got fd entries")
+
+	// fork: the child inherits the fds, and later changes are not shared
+
+	systemMonitor.CopyFdTable(1100, 1200)
+	systemMonitor.AddFdEntry(1100, 5, "File", "/etc/hosts")
+
+	if entry := systemMonitor.GetFdEntry(1200, 4); entry.Operation != "Network" {
+		t.Errorf("[FAIL] Failed to copy the fd table of the parent (%v)", entry)
+		return
+	}
+
+	if entry := systemMonitor.GetFdEntry(1200, 5); entry.Resource != "" {
+		t.Errorf("[FAIL] Shared an fd opened after fork (%v)", entry)
+		return
+	}
+
+	t.Log("[PASS] Copied the fd table of the parent")
+
+	// dup: the new fd refers to the same resource, and an unknown fd overwrites the new fd
+
+	systemMonitor.DuplicateFdEntry(1100, 3, 10)
+
+	if entry := systemMonitor.GetFdEntry(1100, 10); entry.Resource != "/etc/passwd" {
+		t.Errorf("[FAIL] Failed to duplicate an fd entry (%v)", entry)
+		return
+	}
+
+	systemMonitor.DuplicateFdEntry(1100, 20, 10)
+
+	if entry := systemMonitor.GetFdEntry(1100, 10); entry.Resource != "" {
+		t.Errorf("[FAIL] Kept the fd entry replaced by dup2 (%v)", entry)
+		return
+	}
+
+	t.Log("[PASS] Duplicated fd entries")
+
+	// close and exit
+
+	if entry := systemMonitor.DeleteFdEntry(1100, 3); entry.Resource != "/etc/passwd" {
+		t.Errorf("[FAIL] Failed to delete an fd entry (%v)", entry)
+		return
+	}
+
+	if entry := systemMonitor.GetFdEntry(1100, 3); entry.Resource != "" {
+		t.Errorf("[FAIL] Got a deleted fd entry (%v)", entry)
+		return
+	}
+
+	if entry := systemMonitor.DeleteFdEntry(1100, 3); entry.Resource != "" {
+		t.Errorf("[FAIL] Deleted an fd entry twice (%v)", entry)
+		return
+	}
+
+	systemMonitor.DeleteFdTable(1100)
+
+	if entry := systemMonitor.GetFdEntry(1100, 5); entry.Resource != "" {
+		t.Errorf("[FAIL] Got an fd entry of an exited process (%v)", entry)
+		return
+	}
+
+	if entry := systemMonitor.GetFdEntry(1200, 3); entry.Resource != "/etc/passwd" {
+		t.Errorf("[FAIL] Deleted the fd table of the child (%v)", entry)
+		return
+	}
+
+	t.Log("[PASS] Deleted fd entries and fd tables")
+}
+
+// addFakeProc Function
+func addFakeProc(root string, hostPid, pidns, mntns uint32, execPath, status, cmdline string) error {
+	procPath := filepath.Join(root, strconv.FormatUint(uint64(hostPid), 10))
+
+	if err := os.MkdirAll(filepath.Join(procPath, "ns"), 0750); err != nil {
+		return err
+	}
+
+	// procfs shows namespaces and executables as symbolic links
+	if err := os.Symlink(fmt.Sprintf("pid:[%d]", pidns), filepath.Join(procPath, "ns", "pid")); err != nil {
+		return err
+	}
+	if err := os.Symlink(fmt.Sprintf("mnt:[%d]", mntns), filepath.Join(procPath, "ns", "mnt")); err != nil {
+		return err
+	}
+	if execPath != "" {
+		if err := os.Symlink(execPath, filepath.Join(procPath, "exe")); err != nil {
+			return err
+		}
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(procPath, "status"), []byte(status), 0600); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(procPath, "cmdline"), []byte(cmdline), 0600)
+}
+
+func TestBuildPidNodeFromProc(t *testing.T) {
+	// Set up Test Data
+
+	root, err := ioutil.TempDir("", "proc")
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create a fake /proc (%s)", err.Error())
+		return
+	}
+	defer os.RemoveAll(root)
+
+	procRoot = root
+	defer func() { procRoot = "/proc" }()
+
+	// a shell of the host (1000), a shell in a container (1100), and its child (1200)
+	procs := []struct {
+		hostPid uint32
+		pidns   uint32
+		mntns   uint32
+		exe     string
+		status  string
+		cmdline string
+	}{
+		{1000, 4026531836, 4026531840, "/usr/bin/bash", "Name:\tbash\nPPid:\t1\nUid:\t1000\t1000\t1000\t1000\nNSpid:\t1000\n", "bash\x00"},
+		{1100, 4026532100, 4026532101, "/bin/sh", "Name:\tsh\nPPid:\t900\nUid:\t0\t0\t0\t0\nNSpid:\t1100\t1\n", "/bin/sh\x00-c\x00sleep 1000\x00"},
+		{1200, 4026532100, 4026532101, "/bin/sleep (deleted)", "Name:\tsleep\nPPid:\t1100\nUid:\t0\t0\t0\t0\nNSpid:\t1200\t7\n", "sleep\x001000\x00"},
+	}
+
+	for _, proc := range procs {
+		if err := addFakeProc(root, proc.hostPid, proc.pidns, proc.mntns, proc.exe, proc.status, proc.cmdline); err != nil {
+			t.Errorf("[FAIL] Failed to add a fake process (%s)", err.Error())
+			return
+		}
+	}
+
+	// namespaces
+
+	if pidns, mntns, err := GetProcNsIDs(1100); err != nil || pidns != 4026532100 || mntns != 4026532101 {
+		t.Errorf("[FAIL] Failed to get the namespaces of a process (%d, %d, %v)", pidns, mntns, err)
+		return
+	}
+
+	if _, _, err := GetProcNsIDs(1300); err == nil {
+		t.Error("[FAIL] Got the namespaces of a process that does not exist")
+		return
+	}
+
+	t.Log("[PASS] Got the namespaces of processes")
+
+	// status
+
+	status, err := readProcStatus(1100)
+	if err != nil || status["Name"][0] != "sh" || !reflect.DeepEqual(status["NSpid"], []string{"1100", "1"}) {
+		t.Errorf("[FAIL] Failed to read the status of a process (%v, %v)", status, err)
+		return
+	}
+
+	t.Log("[PASS] Read the status of a process")
+
+	// pid nodes
+
+	systemMonitor := &SystemMonitor{}
+
+	node, err := systemMonitor.BuildPidNodeFromProc(1000)
+	if err != nil || node.HostPID != 1000 || node.PID != 1000 || node.PPID != 1 || node.UID != 1000 || node.ExecPath != "/usr/bin/bash" {
+		t.Errorf("[FAIL] Failed to build the pid node of a host process (%v, %v)", node, err)
+		return
+	}
+
+	// the parent of the shell is outside of the pid namespace of the container
+	node, err = systemMonitor.BuildPidNodeFromProc(1100)
+	if err != nil || node.PID != 1 || node.PPID != 0 || node.HostPPID != 900 || node.ExecPath != "/bin/sh -c sleep 1000" || !reflect.DeepEqual(node.Args, []string{"-c", "sleep 1000"}) {
+		t.Errorf("[FAIL] Failed to build the pid node of a container process (%v, %v)", node, err)
+		return
+	}
+
+	node, err = systemMonitor.BuildPidNodeFromProc(1200)
+	if err != nil || node.PID != 7 || node.PPID != 1 || node.Comm != "sleep" || node.ExecPath != "/bin/sleep 1000" {
+		t.Errorf("[FAIL] Failed to build the pid node of a child process in a container (%v, %v)", node, err)
+		return
+	}
+
+	t.Log("[PASS] Built the pid nodes of processes")
+
+	// seed the processes of the container by its cgroup
+
+	ActivePidMap := map[string]tp.PidMap{}
+	ActiveHostPidMap := map[string]tp.PidMap{}
+	ActivePidMapLock := new(sync.RWMutex)
+
+	systemMonitor.ActivePidMap = &ActivePidMap
+	systemMonitor.ActiveHostPidMap = &ActiveHostPidMap
+	systemMonitor.ActivePidMapLock = &ActivePidMapLock
+
+	cgroupDir := filepath.Join(root, "cgroup")
+	if err := os.MkdirAll(cgroupDir, 0750); err != nil {
+		t.Errorf("[FAIL] Failed to create a fake cgroup (%s)", err.Error())
+		return
+	}
+
+	// only the shell is in the cgroup
+	if err := ioutil.WriteFile(filepath.Join(cgroupDir, "cgroup.procs"), []byte("1100\n"), 0600); err != nil {
+		t.Errorf("[FAIL] Failed to create a fake cgroup (%s)", err.Error())
+		return
+	}
+
+	systemMonitor.SeedActivePids("container", cgroupDir, 4026532100, 4026532101)
+
+	if len(ActiveHostPidMap["container"]) != 1 || ActiveHostPidMap["container"][1100].ExecPath != "/bin/sh -c sleep 1000" {
+		t.Errorf("[FAIL] Failed to seed the processes in the cgroup of a container (%v)", ActiveHostPidMap)
+		return
+	}
+
+	// without cgroups, all the processes in the namespaces of the container are seeded
+	systemMonitor.SeedActivePids("container", "", 4026532100, 4026532101)
+
+	if len(ActiveHostPidMap["container"]) != 2 || ActivePidMap["container"][7].HostPID != 1200 {
+		t.Errorf("[FAIL] Failed to seed the processes in the namespaces of a container (%v)", ActiveHostPidMap)
+		return
+	}
+
+	t.Log("[PASS] Seeded the processes of a container")
+
+	// seed the processes of the host (pid 1 is in the host pid namespace, and kernel threads do not have executables)
+
+	if err := addFakeProc(root, 1, 4026531836, 4026531840, "/sbin/init", "Name:\tsystemd\nPPid:\t0\nUid:\t0\t0\t0\t0\nNSpid:\t1\n", "/sbin/init\x00"); err != nil {
+		t.Errorf("[FAIL] Failed to add a fake process (%s)", err.Error())
+		return
+	}
+
+	if err := addFakeProc(root, 2, 4026531836, 4026531840, "", "Name:\tkthreadd\nPPid:\t0\nUid:\t0\t0\t0\t0\nNSpid:\t2\n", ""); err != nil {
+		t.Errorf("[FAIL] Failed to add a fake process (%s)", err.Error())
+		return
+	}
+
+	ActiveHostMap := map[uint32]tp.PidMap{}
+	ActiveHostMapLock := new(sync.RWMutex)
+
+	systemMonitor.ActiveHostMap = &ActiveHostMap
+	systemMonitor.ActiveHostMapLock = &ActiveHostMapLock
+
+	systemMonitor.SeedActiveHostPids()
+
+	if len(ActiveHostMap) != 2 || systemMonitor.GetHostExecPath(1) != "/sbin/init" || systemMonitor.GetHostExecPath(1000) != "/usr/bin/bash" {
+		t.Errorf("[FAIL] Failed to seed the processes of the host (%v)", ActiveHostMap)
+		return
+	}
+
+	t.Log("[PASS] Seeded the processes of the host")
+}
@@ -16,6 +16,8 @@ import (
 	"net"
 	"strconv"
 	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 // ===================== //
@@ -34,8 +36,25 @@ const (
 	sockTypeT  uint8 = 16
 	capT       uint8 = 17
 	syscallT   uint8 = 18
+	dnsT       uint8 = 19
+
+	cloneFlagsT uint8 = 20
+	ptraceReqT  uint8 = 21
+	mountFlagsT uint8 = 22
 )
 
+// ================= //
+// == DNS Message == //
+// ================= //
+
+// DNSMessage Structure
+type DNSMessage struct {
+	Response bool
+	Domain   string
+	QType    string
+	Answers  []string
+}
+
 // ======================= //
 // == Parsing Functions == //
 // ======================= //
@@ -192,6 +211,87 @@ func readSockaddrFromBuff(buff io.Reader) (map[string]string, error) {
 	return res, nil
 }
 
+// parseDNSMessage Function
+func parseDNSMessage(payload []byte) (DNSMessage, error) {
+	res := DNSMessage{Answers: []string{}}
+
+	var parser dnsmessage.Parser
+
+	header, err := parser.Start(payload)
+	if err != nil {
+		return res, err
+	}
+	res.Response = header.Response
+
+	question, err := parser.Question()
+	if err != nil {
+		return res, err
+	}
+	res.Domain = strings.TrimSuffix(question.Name.String(), ".")
+	res.QType = strings.TrimPrefix(question.Type.String(), "Type")
+
+	if !res.Response {
+		return res, nil
+	}
+
+	if err := parser.SkipAllQuestions(); err != nil {
+		return res, nil
+	}
+
+	// the payload can be truncated, so keep the answers parsed so far
+	for {
+		answer, err := parser.Answer()
+		if err != nil {
+			break
+		}
+
+		switch body := answer.Body.(type) {
+		case *dnsmessage.AResource:
+			res.Answers = append(res.Answers, net.IP(body.A[:]).String())
+		case *dnsmessage.AAAAResource:
+			res.Answers = append(res.Answers, net.IP(body.AAAA[:]).String())
+		case *dnsmessage.CNAMEResource:
+			res.Answers = append(res.Answers, strings.TrimSuffix(body.CNAME.String(), "."))
+		}
+	}
+
+	return res, nil
+}
+
+// readDNSFromBuff Function
+func readDNSFromBuff(buff io.Reader) (DNSMessage, error) {
+	size, err := readInt32FromBuff(buff)
+	if err != nil {
+		return DNSMessage{}, fmt.Errorf("error reading dns size: %v", err)
+	}
+
+	payload := []byte{}
+	if size > 0 {
+		payload, err = readByteSliceFromBuff(buff, int(size))
+		if err != nil {
+			return DNSMessage{}, fmt.Errorf("error reading dns message: %v", err)
+		}
+	}
+
+	// DNS over TCP has the length of a message in front of it
+	if len(payload) > 2 && int(binary.BigEndian.Uint16(payload[:2])) == len(payload)-2 {
+		payload = payload[2:]
+	}
+
+	res, err := parseDNSMessage(payload)
+	if (err != nil || res.Domain == "") && len(payload) > 2 {
+		// the message could be a truncated message over TCP (its length does not match the captured size),
+		// and parsing it with the length in front can succeed with an empty domain
+		res, err = parseDNSMessage(payload[2:])
+	}
+
+	if err != nil {
+		return DNSMessage{}, fmt.Errorf("error parsing dns message: %v", err)
+	}
+
+	return res, nil
+}
+
 // getOpenFlags Function
 func getOpenFlags(flags uint32) string {
 	// readOpenFlags prints the `flags` bitmask argument of the `open` syscall
@@ -378,6 +478,239 @@ func getSocketType(st uint32) string {
 	return strings.Join(f, "|")
 }
 
+// getCloneFlags Function
+func getCloneFlags(flags uint32) string {
+	// getCloneFlags prints the `flags` bitmask argument of the `unshare` syscall and the `nstype` argument of the `setns` syscall
+	// http://man7.org/linux/man-pages/man2/unshare.2.html
+	// include/uapi/linux/sched.h
+
+	var f []string
+
+	if flags&0x00000080 == 0x00000080 {
+		f = append(f, "CLONE_NEWTIME")
+	}
+	if flags&0x00000200 == 0x00000200 {
+		f = append(f, "CLONE_FS")
+	}
+	if flags&0x00000400 == 0x00000400 {
+		f = append(f, "CLONE_FILES")
+	}
+	if flags&0x00020000 == 0x00020000 {
+		f = append(f, "CLONE_NEWNS")
+	}
+	if flags&0x00040000 == 0x00040000 {
+		f = append(f, "CLONE_SYSVSEM")
+	}
+	if flags&0x02000000 == 0x02000000 {
+		f = append(f, "CLONE_NEWCGROUP")
+	}
+	if flags&0x04000000 == 0x04000000 {
+		f = append(f, "CLONE_NEWUTS")
+	}
+	if flags&0x08000000 == 0x08000000 {
+		f = append(f, "CLONE_NEWIPC")
+	}
+	if flags&0x10000000 == 0x10000000 {
+		f = append(f, "CLONE_NEWUSER")
+	}
+	if flags&0x20000000 == 0x20000000 {
+		f = append(f, "CLONE_NEWPID")
+	}
+	if flags&0x40000000 == 0x40000000 {
+		f = append(f, "CLONE_NEWNET")
+	}
+	if len(f) == 0 {
+		f = append(f, "0")
+	}
+
+	return strings.Join(f, "|")
+}
+
+// getPtraceRequest Function
+func getPtraceRequest(req int32) string {
+	// getPtraceRequest prints the `request` argument of the `ptrace` syscall
+	// include/uapi/linux/ptrace.h
+
+	var ptraceRequests = map[int32]string{
+		0:      "PTRACE_TRACEME",
+		1:      "PTRACE_PEEKTEXT",
+		2:      "PTRACE_PEEKDATA",
+		3:      "PTRACE_PEEKUSER",
+		4:      "PTRACE_POKETEXT",
+		5:      "PTRACE_POKEDATA",
+		6:      "PTRACE_POKEUSER",
+		7:      "PTRACE_CONT",
+		8:      "PTRACE_KILL",
+		9:      "PTRACE_SINGLESTEP",
+		12:     "PTRACE_GETREGS",
+		13:     "PTRACE_SETREGS",
+		14:     "PTRACE_GETFPREGS",
+		15:     "PTRACE_SETFPREGS",
+		16:     "PTRACE_ATTACH",
+		17:     "PTRACE_DETACH",
+		24:     "PTRACE_SYSCALL",
+		0x4200: "PTRACE_SETOPTIONS",
+		0x4201: "PTRACE_GETEVENTMSG",
+		0x4202: "PTRACE_GETSIGINFO",
+		0x4203: "PTRACE_SETSIGINFO",
+		0x4204: "PTRACE_GETREGSET",
+		0x4205: "PTRACE_SETREGSET",
+		0x4206: "PTRACE_SEIZE",
+		0x4207: "PTRACE_INTERRUPT",
+		0x4208: "PTRACE_LISTEN",
+	}
+
+	var res string
+
+	if reqName, ok := ptraceRequests[req]; ok {
+		res = reqName
+	} else {
+		res = strconv.Itoa(int(req))
+	}
+
+	return res
+}
+
+// getMountFlags Function
+func getMountFlags(flags uint32) string {
+	// getMountFlags prints the `mountflags` bitmask argument of the `mount` syscall
+	// http://man7.org/linux/man-pages/man2/mount.2.html
+	// include/uapi/linux/mount.h
+
+	// the magic number in the upper 16 bits is ignored by the kernel
+	if flags&0xffff0000 == 0xc0ed0000 {
+		flags = flags & 0x0000ffff
+	}
+
+	var f []string
+
+	if flags&1 == 1 {
+		f = append(f, "MS_RDONLY")
+	}
+	if flags&2 == 2 {
+		f = append(f, "MS_NOSUID")
+	}
+	if flags&4 == 4 {
+		f = append(f, "MS_NODEV")
+	}
+	if flags&8 == 8 {
+		f = append(f, "MS_NOEXEC")
+	}
+	if flags&16 == 16 {
+		f = append(f, "MS_SYNCHRONOUS")
+	}
+	if flags&32 == 32 {
+		f = append(f, "MS_REMOUNT")
+	}
+	if flags&64 == 64 {
+		f = append(f, "MS_MANDLOCK")
+	}
+	if flags&128 == 128 {
+		f = append(f, "MS_DIRSYNC")
+	}
+	if flags&1024 == 1024 {
+		f = append(f, "MS_NOATIME")
+	}
+	if flags&2048 == 2048 {
+		f = append(f, "MS_NODIRATIME")
+	}
+	if flags&4096 == 4096 {
+		f = append(f, "MS_BIND")
+	}
+	if flags&8192 == 8192 {
+		f = append(f, "MS_MOVE")
+	}
+	if flags&16384 == 16384 {
+		f = append(f, "MS_REC")
+	}
+	if flags&(1<<17) == (1 << 17) {
+		f = append(f, "MS_UNBINDABLE")
+	}
+	if flags&(1<<18) == (1 << 18) {
+		f = append(f, "MS_PRIVATE")
+	}
+	if flags&(1<<19) == (1 << 19) {
+		f = append(f, "MS_SLAVE")
+	}
+	if flags&(1<<20) == (1 << 20) {
+		f = append(f, "MS_SHARED")
+	}
+	if flags&(1<<21) == (1 << 21) {
+		f = append(f, "MS_RELATIME")
+	}
+	if flags&(1<<24) == (1 << 24) {
+		f = append(f, "MS_STRICTATIME")
+	}
+	if flags&(1<<25) == (1 << 25) {
+		f = append(f, "MS_LAZYTIME")
+	}
+	if len(f) == 0 {
+		f = append(f, "0")
+	}
+
+	return strings.Join(f, "|")
+}
+
+// getFilelessExecType Function
+func getFilelessExecType(name string) string {
+	// memfd files are named "memfd:<name>", and the others are binaries unlinked from their directories
+	if strings.HasPrefix(name, "memfd:") {
+		return name
+	}
+
+	return "deleted:" + name
+}
+
+// getBPFProgType Function
+func getBPFProgType(progType uint32) string {
+	// getBPFProgType prints the `prog_type` field of the `bpf_attr` argument of the `bpf` syscall
+	// include/uapi/linux/bpf.h
+
+	var progTypes = map[uint32]string{
+		0:  "BPF_PROG_TYPE_UNSPEC",
+		1:  "BPF_PROG_TYPE_SOCKET_FILTER",
+		2:  "BPF_PROG_TYPE_KPROBE",
+		3:  "BPF_PROG_TYPE_SCHED_CLS",
+		4:  "BPF_PROG_TYPE_SCHED_ACT",
+		5:  "BPF_PROG_TYPE_TRACEPOINT",
+		6:  "BPF_PROG_TYPE_XDP",
+		7:  "BPF_PROG_TYPE_PERF_EVENT",
+		8:  "BPF_PROG_TYPE_CGROUP_SKB",
+		9:  "BPF_PROG_TYPE_CGROUP_SOCK",
+		10: "BPF_PROG_TYPE_LWT_IN",
+		11: "BPF_PROG_TYPE_LWT_OUT",
+		12: "BPF_PROG_TYPE_LWT_XMIT",
+		13: "BPF_PROG_TYPE_SOCK_OPS",
+		14: "BPF_PROG_TYPE_SK_SKB",
+		15: "BPF_PROG_TYPE_CGROUP_DEVICE",
+		16: "BPF_PROG_TYPE_SK_MSG",
+		17: "BPF_PROG_TYPE_RAW_TRACEPOINT",
+		18: "BPF_PROG_TYPE_CGROUP_SOCK_ADDR",
+		19: "BPF_PROG_TYPE_LWT_SEG6LOCAL",
+		20: "BPF_PROG_TYPE_LIRC_MODE2",
+		21: "BPF_PROG_TYPE_SK_REUSEPORT",
+		22: "BPF_PROG_TYPE_FLOW_DISSECTOR",
+		23: "BPF_PROG_TYPE_CGROUP_SYSCTL",
+		24: "BPF_PROG_TYPE_RAW_TRACEPOINT_WRITABLE",
+		25: "BPF_PROG_TYPE_CGROUP_SOCKOPT",
+		26: "BPF_PROG_TYPE_TRACING",
+		27: "BPF_PROG_TYPE_STRUCT_OPS",
+		28: "BPF_PROG_TYPE_EXT",
+		29: "BPF_PROG_TYPE_LSM",
+		30: "BPF_PROG_TYPE_SK_LOOKUP",
+	}
+
+	var res string
+
+	if progTypeName, ok := progTypes[progType]; ok {
+		res = progTypeName
+	} else {
+		res = strconv.Itoa(int(progType))
+	}
+
+	return res
+}
+
 // getCapabilityName Function
 func getCapabilityName(cap int32) string {
 	// getCapabilityName prints the `capability` bitmask argument of the `cap_capable` function
@@ -1027,6 +1360,30 @@ func readArgFromBuff(dataBuff io.Reader) (interface{}, error) {
 			return nil, err
 		}
 		res = getSocketType(t)
+	case cloneFlagsT:
+		flags, err := readUInt32FromBuff(dataBuff)
+		if err != nil {
+			return nil, err
+		}
+		res = getCloneFlags(flags)
+	case ptraceReqT:
+		req, err := readInt32FromBuff(dataBuff)
+		if err != nil {
+			return nil, err
+		}
+		res = getPtraceRequest(req)
+	case mountFlagsT:
+		flags, err := readUInt32FromBuff(dataBuff)
+		if err != nil {
+			return nil, err
+		}
+		res = getMountFlags(flags)
+	case dnsT:
+		msg, err := readDNSFromBuff(dataBuff)
+		if err != nil {
+			return nil, err
+		}
+		res = msg
 	default:
 		return nil, fmt.Errorf("error unknown arg type %v", at)
 	}
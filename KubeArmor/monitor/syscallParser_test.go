@@ -0,0 +1,143 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package monitor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// buildDNSMessage Function
+func buildDNSMessage(response bool, domain string, qtype dnsmessage.Type, answers []dnsmessage.Resource) ([]byte, error) {
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: 1, Response: response, RecursionDesired: true})
+	builder.EnableCompression()
+
+	if err := builder.StartQuestions(); err != nil {
+		return nil, err
+	}
+
+	if err := builder.Question(dnsmessage.Question{Name: dnsmessage.MustNewName(domain), Type: qtype, Class: dnsmessage.ClassINET}); err != nil {
+		return nil, err
+	}
+
+	if err := builder.StartAnswers(); err != nil {
+		return nil, err
+	}
+
+	for _, answer := range answers {
+		var err error
+
+		switch body := answer.Body.(type) {
+		case *dnsmessage.AResource:
+			err = builder.AResource(answer.Header, *body)
+		case *dnsmessage.AAAAResource:
+			err = builder.AAAAResource(answer.Header, *body)
+		case *dnsmessage.CNAMEResource:
+			err = builder.CNAMEResource(answer.Header, *body)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return builder.Finish()
+}
+
+// buildDNSBuff Function
+func buildDNSBuff(payload []byte) *bytes.Buffer {
+	// the size of the payload (int) and the payload, as save_dns_to_buffer writes them
+	buff := new(bytes.Buffer)
+	_ = binary.Write(buff, binary.LittleEndian, int32(len(payload)))
+	buff.Write(payload)
+	return buff
+}
+
+func TestReadDNSFromBuff(t *testing.T) {
+	// Set up Test Data
+
+	query, err := buildDNSMessage(false, "www.kubearmor.io.", dnsmessage.TypeA, nil)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to build a DNS query (%s)", err.Error())
+		return
+	}
+
+	header := func(name string, rtype dnsmessage.Type) dnsmessage.ResourceHeader {
+		return dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(name), Type: rtype, Class: dnsmessage.ClassINET, TTL: 300}
+	}
+
+	response, err := buildDNSMessage(true, "www.kubearmor.io.", dnsmessage.TypeA, []dnsmessage.Resource{
+		{Header: header("www.kubearmor.io.", dnsmessage.TypeCNAME), Body: &dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName("kubearmor.github.io.")}},
+		{Header: header("kubearmor.github.io.", dnsmessage.TypeA), Body: &dnsmessage.AResource{A: [4]byte{185, 199, 108, 153}}},
+		{Header: header("kubearmor.github.io.", dnsmessage.TypeAAAA), Body: &dnsmessage.AAAAResource{AAAA: [16]byte{0x26, 0x06, 0x50, 0xc0, 0x80, 0x00, 15: 0x01}}},
+	})
+	if err != nil {
+		t.Errorf("[FAIL] Failed to build a DNS response (%s)", err.Error())
+		return
+	}
+
+	// DNS over TCP
+	tcpResponse := make([]byte, 2, len(response)+2)
+	binary.BigEndian.PutUint16(tcpResponse, uint16(len(response)))
+	tcpResponse = append(tcpResponse, response...)
+
+	tests := []struct {
+		name    string
+		payload []byte
+		msg     DNSMessage
+		valid   bool
+	}{
+		{"query", query, DNSMessage{Response: false, Domain: "www.kubearmor.io", QType: "A", Answers: []string{}}, true},
+		{"response", response, DNSMessage{Response: true, Domain: "www.kubearmor.io", QType: "A", Answers: []string{"kubearmor.github.io", "185.199.108.153", "2606:50c0:8000::1"}}, true},
+		{"tcp response", tcpResponse, DNSMessage{Response: true, Domain: "www.kubearmor.io", QType: "A", Answers: []string{"kubearmor.github.io", "185.199.108.153", "2606:50c0:8000::1"}}, true},
+		// the answers in the captured part are kept (the last answer is cut off)
+		{"truncated response", response[:len(response)-4], DNSMessage{Response: true, Domain: "www.kubearmor.io", QType: "A", Answers: []string{"kubearmor.github.io", "185.199.108.153"}}, true},
+		// the length in front of a truncated tcp message does not match the captured size
+		{"truncated tcp response", tcpResponse[:len(tcpResponse)-4], DNSMessage{Response: true, Domain: "www.kubearmor.io", QType: "A", Answers: []string{"kubearmor.github.io", "185.199.108.153"}}, true},
+		{"truncated question", query[:len(query)-6], DNSMessage{}, false},
+		{"truncated header", query[:8], DNSMessage{}, false},
+		{"empty payload", []byte{}, DNSMessage{}, false},
+	}
+
+	for _, test := range tests {
+		msg, err := readDNSFromBuff(buildDNSBuff(test.payload))
+
+		if !test.valid {
+			if err == nil {
+				t.Errorf("[FAIL] Parsed an invalid DNS message (%s, %v)", test.name, msg)
+				return
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("[FAIL] Failed to parse a DNS message (%s, %s)", test.name, err.Error())
+			return
+		}
+
+		if !reflect.DeepEqual(msg, test.msg) {
+			t.Errorf("[FAIL] Parsed a DNS message incorrectly (%s, %v != %v)", test.name, msg, test.msg)
+			return
+		}
+	}
+
+	t.Log("[PASS] Parsed DNS queries and responses")
+
+	// the size is larger than the captured payload
+
+	buff := new(bytes.Buffer)
+	_ = binary.Write(buff, binary.LittleEndian, int32(len(query)+10))
+	buff.Write(query)
+
+	if _, err := readDNSFromBuff(buff); err == nil {
+		t.Error("[FAIL] Read a DNS message beyond the buffer")
+		return
+	}
+
+	t.Log("[PASS] Rejected a DNS message beyond the buffer")
+}
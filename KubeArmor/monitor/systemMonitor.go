@@ -33,15 +33,46 @@ const (
 	SysOpenAt = 257
 	SysClose  = 3
 
+	SysDup   = 32
+	SysDup2  = 33
+	SysDup3  = 292
+	SysFcntl = 72
+
+	SysMemfdCreate = 319
+
 	SysSocket  = 41
 	SysConnect = 42
 	SysAccept  = 43
 	SysBind    = 49
 	SysListen  = 50
 
+	SysSendTo   = 44
+	SysRecvFrom = 45
+	SysSendMsg  = 46
+	SysRecvMsg  = 47
+
+	SysSendMMsg = 307
+
 	SysExecve   = 59
 	SysExecveAt = 322
 	DoExit      = 351
+	DoFork      = 352
+
+	SysInitModule   = 175
+	SysDeleteModule = 176
+	SysFinitModule  = 313
+	SysBPF          = 321
+
+	SysSetUID    = 105
+	SysSetGID    = 106
+	SysSetReUID  = 113
+	SysSetReGID  = 114
+	SysSetResUID = 117
+	SysSetResGID = 119
+	SysSetNS     = 308
+	SysUnshare   = 272
+	SysMount     = 165
+	SysPtrace    = 101
 )
 
 // SystemMonitor Constant Values
@@ -60,6 +91,16 @@ type NsKey struct {
 	MntNS uint32
 }
 
+// ============================= //
+// == File Descriptor Context == //
+// ============================= //
+
+// FdEntry Structure
+type FdEntry struct {
+	Operation string
+	Resource  string
+}
+
 // ===================== //
 // == Syscall Context == //
 // ===================== //
@@ -71,6 +112,8 @@ type SyscallContext struct {
 	PidID uint32
 	MntID uint32
 
+	CgroupID uint64
+
 	HostPPID uint32
 	HostPID  uint32
 
@@ -128,9 +171,28 @@ type SystemMonitor struct {
 	NsMap     map[NsKey]string
 	NsMapLock *sync.RWMutex
 
+	// PidID + MntID -> container id (in untracked namespaces)
+	IgnoredNsMap map[NsKey]string
+
+	// cgroup id -> container id
+	CgroupMap map[uint64]string
+
+	// report the fallback to namespaces only once
+	CgroupFallbackOnce *sync.Once
+
+	// host pid -> fd -> file path or socket
+	FdMap     map[uint32]map[int32]FdEntry
+	FdMapLock *sync.RWMutex
+
 	// system monitor (for container)
 	BpfModule *bcc.Module
 
+	// namespaces to skip in the kernel (for container)
+	NsIgnoreMap *bcc.Table
+
+	// cgroup ids to trace in the kernel (for container)
+	CgroupIDMap *bcc.Table
+
 	// context + args (for container)
 	ContextChan chan ContextCombined
 
@@ -157,6 +219,10 @@ type SystemMonitor struct {
 	// lists to skip
 	UntrackedNamespaces []string
 
+	// host namespaces
+	HostPidNS uint32
+	HostMntNS uint32
+
 	UptimeTimeStamp float64
 	HostByteOrder   binary.ByteOrder
 
@@ -195,11 +261,21 @@ func NewSystemMonitor(feeder *fd.Feeder, enableHostPolicy bool, containers *map[
 	mon.NsMap = make(map[NsKey]string)
 	mon.NsMapLock = new(sync.RWMutex)
 
+	mon.IgnoredNsMap = make(map[NsKey]string)
+
+	mon.CgroupMap = make(map[uint64]string)
+	mon.CgroupFallbackOnce = new(sync.Once)
+
+	mon.FdMap = make(map[uint32]map[int32]FdEntry)
+	mon.FdMapLock = new(sync.RWMutex)
+
 	mon.ContextChan = make(chan ContextCombined, 4096)
 	mon.HostContextChan = make(chan ContextCombined, 4096)
 
 	mon.UntrackedNamespaces = []string{"kube-system", "kubearmor"}
 
+	mon.HostPidNS, mon.HostMntNS, _ = GetProcNsIDs(1)
+
 	mon.UptimeTimeStamp = kl.GetUptimeTimestamp()
 	mon.HostByteOrder = bcc.GetHostByteOrder()
 
@@ -284,7 +360,9 @@ func (mon *SystemMonitor) InitBPF() error {
 	mon.Logger.Print("Initialized the eBPF program")
 
 	sysPrefix := bcc.GetSyscallPrefix()
-	systemCalls := []string{"open", "openat", "execve", "execveat", "socket", "connect", "accept", "bind", "listen"}
+	systemCalls := []string{"open", "openat", "close", "memfd_create", "dup", "dup2", "dup3", "fcntl", "execve", "execveat", "socket", "connect", "accept", "bind", "listen", "sendto", "recvfrom", "sendmsg", "recvmsg", "sendmmsg",
+		"init_module", "finit_module", "delete_module", "bpf",
+		"setuid", "setgid", "setreuid", "setregid", "setresuid", "setresgid", "setns", "unshare", "mount", "ptrace"}
 
 	for _, syscallName := range systemCalls {
 		kp, err := mon.BpfModule.LoadKprobe(fmt.Sprintf("syscall__%s", syscallName))
@@ -305,7 +383,7 @@ func (mon *SystemMonitor) InitBPF() error {
 		}
 	}
 
-	tracepoints := []string{"do_exit"}
+	tracepoints := []string{"do_exit", "wake_up_new_task"}
 
 	for _, tracepoint := range tracepoints {
 		kp, err := mon.BpfModule.LoadKprobe(fmt.Sprintf("trace_%s", tracepoint))
@@ -318,6 +396,9 @@ func (mon *SystemMonitor) InitBPF() error {
 		}
 	}
 
+	mon.NsIgnoreMap = bcc.NewTable(mon.BpfModule.TableId("ns_ignore_map"), mon.BpfModule)
+	mon.CgroupIDMap = bcc.NewTable(mon.BpfModule.TableId("cgroup_map"), mon.BpfModule)
+
 	eventsTable := bcc.NewTable(mon.BpfModule.TableId("sys_events"), mon.BpfModule)
 	mon.SyscallChannel = make(chan []byte, 8192)
 	mon.SyscallLostChannel = make(chan uint64)
@@ -347,7 +428,7 @@ func (mon *SystemMonitor) InitBPF() error {
 			}
 		}
 
-		tracepoints := []string{"do_exit"}
+		tracepoints := []string{"do_exit", "wake_up_new_task"}
 
 		for _, tracepoint := range tracepoints {
 			kp, err := mon.HostBpfModule.LoadKprobe(fmt.Sprintf("trace_%s", tracepoint))
@@ -422,9 +503,6 @@ func (mon *SystemMonitor) TraceSyscall() {
 		return
 	}
 
-	Containers := *(mon.Containers)
-	ContainersLock := *(mon.ContainersLock)
-
 	execLogMap := map[uint32]tp.Log{}
 
 	for {
@@ -450,18 +528,10 @@ func (mon *SystemMonitor) TraceSyscall() {
 
 			containerID := ""
 
-			if ctx.PidID != 0 && ctx.MntID != 0 {
-				containerID = mon.LookupContainerID(ctx.PidID, ctx.MntID, ctx.HostPPID, ctx.HostPID)
-
-				if containerID != "" {
-					ContainersLock.RLock()
-					namespace := Containers[containerID].NamespaceName
-					if kl.ContainsElement(mon.UntrackedNamespaces, namespace) {
-						ContainersLock.RUnlock()
-						continue
-					}
-					ContainersLock.RUnlock()
-				}
+			if ctx.CgroupID != 0 || (ctx.PidID != 0 && ctx.MntID != 0) {
+				// containers in untracked namespaces are filtered out in the kernel,
+				// and the remaining events from them are not found in CgroupMap and NsMap
+				containerID = mon.LookupContainerID(ctx.CgroupID, ctx.PidID, ctx.MntID, ctx.HostPPID, ctx.HostPID)
 			}
 
 			if ctx.PidID != 0 && ctx.MntID != 0 && containerID == "" {
@@ -501,6 +571,10 @@ func (mon *SystemMonitor) TraceSyscall() {
 								log.Resource = log.Resource + " " + arg
 							}
 						}
+
+						if len(val) > 1 {
+							log.Args = val[1:]
+						}
 					}
 
 					log.Operation = "Process"
@@ -510,7 +584,7 @@ func (mon *SystemMonitor) TraceSyscall() {
 
 					execLogMap[ctx.HostPID] = log
 
-				} else if len(args) == 0 { // return
+				} else if len(args) <= 1 { // return
 					// get the stored log
 
 					log := execLogMap[ctx.HostPID]
@@ -519,6 +593,15 @@ func (mon *SystemMonitor) TraceSyscall() {
 
 					delete(execLogMap, ctx.HostPID)
 
+					// mark fileless executions
+
+					if len(args) == 1 {
+						if val, ok := args[0].(string); ok {
+							log.Fileless = true
+							log.Data = log.Data + " fileless=" + getFilelessExecType(val)
+						}
+					}
+
 					// get error message
 
 					if ctx.Retval < 0 {
@@ -572,6 +655,10 @@ func (mon *SystemMonitor) TraceSyscall() {
 								log.Resource = log.Resource + " " + arg
 							}
 						}
+
+						if len(val) > 1 {
+							log.Args = val[1:]
+						}
 					}
 
 					if val, ok := args[3].(string); ok {
@@ -585,7 +672,7 @@ func (mon *SystemMonitor) TraceSyscall() {
 
 					execLogMap[ctx.HostPID] = log
 
-				} else if len(args) == 0 { // return
+				} else if len(args) <= 1 { // return
 					// get the stored log
 
 					log := execLogMap[ctx.HostPID]
@@ -594,6 +681,15 @@ func (mon *SystemMonitor) TraceSyscall() {
 
 					delete(execLogMap, ctx.HostPID)
 
+					// mark fileless executions
+
+					if len(args) == 1 {
+						if val, ok := args[0].(string); ok {
+							log.Fileless = true
+							log.Data = log.Data + " fileless=" + getFilelessExecType(val)
+						}
+					}
+
 					// get error message
 
 					if ctx.Retval < 0 {
@@ -694,6 +790,10 @@ func (mon *SystemMonitor) TraceHostSyscall() {
 								log.Resource = log.Resource + " " + arg
 							}
 						}
+
+						if len(val) > 1 {
+							log.Args = val[1:]
+						}
 					}
 
 					log.Operation = "Process"
@@ -703,7 +803,7 @@ func (mon *SystemMonitor) TraceHostSyscall() {
 
 					execLogMap[ctx.HostPID] = log
 
-				} else if len(args) == 0 { // return
+				} else if len(args) <= 1 { // return
 					// get the stored log
 
 					log := execLogMap[ctx.HostPID]
@@ -712,6 +812,15 @@ func (mon *SystemMonitor) TraceHostSyscall() {
 
 					delete(execLogMap, ctx.HostPID)
 
+					// mark fileless executions
+
+					if len(args) == 1 {
+						if val, ok := args[0].(string); ok {
+							log.Fileless = true
+							log.Data = log.Data + " fileless=" + getFilelessExecType(val)
+						}
+					}
+
 					// get error message
 
 					if ctx.Retval < 0 {
@@ -765,6 +874,10 @@ func (mon *SystemMonitor) TraceHostSyscall() {
 								log.Resource = log.Resource + " " + arg
 							}
 						}
+
+						if len(val) > 1 {
+							log.Args = val[1:]
+						}
 					}
 
 					if val, ok := args[3].(string); ok {
@@ -778,7 +891,7 @@ func (mon *SystemMonitor) TraceHostSyscall() {
 
 					execLogMap[ctx.HostPID] = log
 
-				} else if len(args) == 0 { // return
+				} else if len(args) <= 1 { // return
 					// get the stored log
 
 					log := execLogMap[ctx.HostPID]
@@ -787,6 +900,15 @@ func (mon *SystemMonitor) TraceHostSyscall() {
 
 					delete(execLogMap, ctx.HostPID)
 
+					// mark fileless executions
+
+					if len(args) == 1 {
+						if val, ok := args[0].(string); ok {
+							log.Fileless = true
+							log.Data = log.Data + " fileless=" + getFilelessExecType(val)
+						}
+					}
+
 					// get error message
 
 					if ctx.Retval < 0 {
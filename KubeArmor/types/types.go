@@ -4,6 +4,7 @@
 package types
 
 import (
+	"net"
 	"regexp"
 	"time"
 
@@ -11,6 +12,29 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// =================== //
+// == Configuration == //
+// =================== //
+
+// KubeArmorConfig Structure
+type KubeArmorConfig struct {
+	ClusterName string // cluster name (CLUSTER_NAME if empty)
+	GRPCPort    string // gRPC port number
+	LogPath     string // log file path {path|stdout|none}
+	LogFilter   string // kinds of alerts and logs {policy|system|all}
+
+	UntrackedNamespaces string // namespaces not to be monitored (comma-separated)
+
+	EnableHostPolicy     bool // enable host policies
+	EnableEnforcerPerPod bool // enable the enforcer per pod
+	EnableK8sEvents      bool // report policy violations as Kubernetes events
+
+	PolicyDiscoveryPath     string        // directory to write discovered policies (disabled if empty)
+	PolicyDiscoveryInterval time.Duration // interval to write discovered policies
+
+	EnablePartialSeccompProfiles bool // write seccomp profiles of the traced syscalls
+}
+
 // ============ //
 // == Docker == //
 // ============ //
@@ -30,6 +54,9 @@ type Container struct {
 	PidNS uint32 `json:"pidns"`
 	MntNS uint32 `json:"mntns"`
 
+	CgroupID  uint64 `json:"cgroupID"`
+	CgroupDir string `json:"cgroupDir"`
+
 	// == //
 
 	PolicyEnabled int `json:"policyEnabled"`
@@ -57,10 +84,14 @@ type EndPoint struct {
 	Labels     []string `json:"labels"`
 	Identities []string `json:"identities"`
 
+	NamespaceLabels []string `json:"namespaceLabels"`
+
 	Containers       []string          `json:"containers"`
+	ContainerNames   map[string]string `json:"containerNames"`
 	HostVolumes      []HostVolumeMount `json:"hostVolumes"`
 	AppArmorProfiles map[string]string `json:"apparmorProfiles"`
 	SELinuxProfiles  map[string]string `json:"selinuxProfiles"`
+	SeccompProfiles  map[string]string `json:"seccompProfiles"`
 
 	SecurityPolicies []SecurityPolicy `json:"securityPolicies"`
 
@@ -93,6 +124,69 @@ type K8sPodEvent struct {
 	Object v1.Pod `json:"object"`
 }
 
+// K8sNamespace Structure
+type K8sNamespace struct {
+	Name        string
+	Annotations map[string]string
+	Labels      map[string]string
+}
+
+// K8sNamespaceEvent Structure
+type K8sNamespaceEvent struct {
+	Type   string       `json:"type"`
+	Object v1.Namespace `json:"object"`
+}
+
+// K8sViolationEvent Structure
+type K8sViolationEvent struct {
+	NamespaceName string
+	PodName       string
+	PolicyName    string
+
+	// the name of the Kubernetes event (empty if not created yet)
+	EventName string
+
+	Type    string
+	Message string
+
+	// the number of alerts written into the event and not written yet
+	Count   int32
+	Pending int32
+
+	FirstTime time.Time
+	LastTime  time.Time
+}
+
+// DiscoveredWorkload Structure
+type DiscoveredWorkload struct {
+	NamespaceName string
+	WorkloadName  string
+
+	// the labels of the pods (used as the selector)
+	Labels map[string]string
+
+	// observed resource -> sources
+	Processes map[string][]string
+	Files     map[string][]string
+	Protocols map[string][]string
+
+	// updated in the current window
+	Updated bool
+}
+
+// PartialSeccompProfile Structure
+type PartialSeccompProfile struct {
+	NamespaceName string
+	WorkloadName  string
+	ContainerName string
+
+	// the syscalls traced by the system monitor (not all the syscalls of a container)
+	Syscalls []string
+
+	// updated in the current window
+	Updated bool
+}
+
 // K8sPolicyStatus Structure
 type K8sPolicyStatus struct {
 	Status string `json:"status,omitempty"`
@@ -179,6 +273,18 @@ type Log struct {
 	Action    string `json:"action,omitempty"`
 	Result    string `json:"result"`
 
+	// fileless execution (memfd or deleted binary)
+	Fileless bool `json:"fileless,omitempty"`
+
+	// result of a response action (Kill or Stop)
+	Response string `json:"response,omitempty"`
+
+	// process arguments (not exported, already in resource)
+	Args []string `json:"-"`
+
+	// quarantine actions of the matched policy (not exported)
+	QuarantineActions []string `json:"-"`
+
 	// == //
 
 	PolicyEnabled int `json:"policyEnabled,omitempty"`
@@ -201,16 +307,68 @@ type MatchPolicy struct {
 	Operation    string
 	ResourceType string
 	Resource     string
+	Recursive    bool
 
 	Regexp *regexp.Regexp
 	Native bool
 
+	Args       []MatchArgType
+	ArgRegexps []*regexp.Regexp
+
+	FromUser MatchUserType
+
+	IPNet *net.IPNet
+	Ports []int
+
+	HostNamespace bool
+
+	Containers []string
+
+	Response ResponseType
+
 	Action string
 }
 
+// MatchPathNode Structure
+type MatchPathNode struct {
+	Children map[byte]*MatchPathNode
+
+	// the positions of the policies whose resources end at this node
+	Policies []int
+}
+
+// MatchPolicyIndex Structure
+type MatchPolicyIndex struct {
+	// operation -> the positions of the policies to check one by one
+	Scans map[string][]int
+
+	// operation -> the paths and directories of the policies (matched from the beginning of a resource)
+	Paths map[string]*MatchPathNode
+
+	// operation -> resource -> the positions of the policies (matched by the first field of a resource)
+	Resources map[string]map[string][]int
+
+	// operation -> the positions of the allow policies
+	Allows map[string][]int
+
+	// the positions of the native policies
+	Natives []int
+
+	// the sources of the policies (compiled as AppArmor globs)
+	Sources       []string
+	SourceRegexps []*regexp.Regexp
+	SourceIDs     []int
+
+	// the literal prefixes of the glob patterns
+	GlobPrefixes []string
+}
+
 // MatchPolicies Structure
 type MatchPolicies struct {
 	Policies []MatchPolicy
+
+	// the index of the policies (built whenever the policies are updated)
+	Index MatchPolicyIndex
 }
 
 // ===================== //
@@ -224,9 +382,27 @@ const (
 	KubeArmorPolicyAudited  = 2
 )
 
+// MatchExpressionType Structure
+type MatchExpressionType struct {
+	Key      string   `json:"key"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values,omitempty"`
+}
+
 // SelectorType Structure
 type SelectorType struct {
-	MatchNames  map[string]string `json:"matchNames,omitempty"`
+	MatchNames       map[string]string     `json:"matchNames,omitempty"`
+	MatchLabels      map[string]string     `json:"matchLabels,omitempty"`
+	MatchExpressions []MatchExpressionType `json:"matchExpressions,omitempty"`
+
+	Containers []string `json:"containers,omitempty"`
+
+	Identities []string `json:"identities,omitempty"` // set during policy update
+}
+
+// NamespaceSelectorType Structure
+type NamespaceSelectorType struct {
+	MatchNames  []string          `json:"matchNames,omitempty"`
 	MatchLabels map[string]string `json:"matchLabels,omitempty"`
 
 	Identities []string `json:"identities,omitempty"` // set during policy update
@@ -239,6 +415,19 @@ type MatchSourceType struct {
 	Recursive bool   `json:"recursive,omitempty"`
 }
 
+// MatchArgType Structure
+type MatchArgType struct {
+	Arg   string `json:"arg"`
+	Type  string `json:"type,omitempty"`  // Exact (default), Glob, or Regexp
+	Index int    `json:"index,omitempty"` // 1 for the first argument (any argument by default)
+}
+
+// MatchUserType Structure
+type MatchUserType struct {
+	MatchUIDs   []int `json:"matchUIDs,omitempty"`
+	ExcludeUIDs []int `json:"excludeUIDs,omitempty"`
+}
+
 // ProcessPathType Structure
 type ProcessPathType struct {
 	Severity int      `json:"severity,omitempty"`
@@ -247,7 +436,9 @@ type ProcessPathType struct {
 
 	Path       string            `json:"path"`
 	OwnerOnly  bool              `json:"ownerOnly,omitempty"`
+	MatchArgs  []MatchArgType    `json:"matchArgs,omitempty"`
 	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+	FromUser   MatchUserType     `json:"fromUser,omitempty"`
 
 	Action string `json:"action,omitempty"`
 }
@@ -261,7 +452,9 @@ type ProcessDirectoryType struct {
 	Directory  string            `json:"dir"`
 	Recursive  bool              `json:"recursive,omitempty"`
 	OwnerOnly  bool              `json:"ownerOnly,omitempty"`
+	MatchArgs  []MatchArgType    `json:"matchArgs,omitempty"`
 	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+	FromUser   MatchUserType     `json:"fromUser,omitempty"`
 
 	Action string `json:"action,omitempty"`
 }
@@ -278,6 +471,18 @@ type ProcessPatternType struct {
 	Action string `json:"action,omitempty"`
 }
 
+// ProcessFilelessType Structure
+type ProcessFilelessType struct {
+	Severity int      `json:"severity,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Message  string   `json:"message,omitempty"`
+
+	Type       string            `json:"type"`
+	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+
+	Action string `json:"action,omitempty"`
+}
+
 // ProcessType Structure
 type ProcessType struct {
 	Severity int      `json:"severity,omitempty"`
@@ -287,6 +492,7 @@ type ProcessType struct {
 	MatchPaths       []ProcessPathType      `json:"matchPaths,omitempty"`
 	MatchDirectories []ProcessDirectoryType `json:"matchDirectories,omitempty"`
 	MatchPatterns    []ProcessPatternType   `json:"matchPatterns,omitempty"`
+	MatchFileless    []ProcessFilelessType  `json:"matchFileless,omitempty"`
 
 	Action string `json:"action,omitempty"`
 }
@@ -301,6 +507,7 @@ type FilePathType struct {
 	ReadOnly   bool              `json:"readOnly,omitempty"`
 	OwnerOnly  bool              `json:"ownerOnly,omitempty"`
 	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+	FromUser   MatchUserType     `json:"fromUser,omitempty"`
 
 	Action string `json:"action,omitempty"`
 }
@@ -316,6 +523,7 @@ type FileDirectoryType struct {
 	Recursive  bool              `json:"recursive,omitempty"`
 	OwnerOnly  bool              `json:"ownerOnly,omitempty"`
 	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+	FromUser   MatchUserType     `json:"fromUser,omitempty"`
 
 	Action string `json:"action,omitempty"`
 }
@@ -354,6 +562,47 @@ type NetworkProtocolType struct {
 
 	Protocol   string            `json:"protocol"`
 	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+	FromUser   MatchUserType     `json:"fromUser,omitempty"`
+
+	Action string `json:"action,omitempty"`
+}
+
+// NetworkIPType Structure
+type NetworkIPType struct {
+	Severity int      `json:"severity,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Message  string   `json:"message,omitempty"`
+
+	IP         string            `json:"ip"`
+	Ports      []int             `json:"ports,omitempty"`
+	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+	FromUser   MatchUserType     `json:"fromUser,omitempty"`
+
+	Action string `json:"action,omitempty"`
+}
+
+// NetworkPortType Structure
+type NetworkPortType struct {
+	Severity int      `json:"severity,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Message  string   `json:"message,omitempty"`
+
+	Port       int               `json:"port"`
+	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+	FromUser   MatchUserType     `json:"fromUser,omitempty"`
+
+	Action string `json:"action,omitempty"`
+}
+
+// NetworkDomainType Structure
+type NetworkDomainType struct {
+	Severity int      `json:"severity,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Message  string   `json:"message,omitempty"`
+
+	Domain     string            `json:"domain"`
+	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+	FromUser   MatchUserType     `json:"fromUser,omitempty"`
 
 	Action string `json:"action,omitempty"`
 }
@@ -365,6 +614,9 @@ type NetworkType struct {
 	Message  string   `json:"message,omitempty"`
 
 	MatchProtocols []NetworkProtocolType `json:"matchProtocols,omitempty"`
+	MatchIPs       []NetworkIPType       `json:"matchIPs,omitempty"`
+	MatchPorts     []NetworkPortType     `json:"matchPorts,omitempty"`
+	MatchDomains   []NetworkDomainType   `json:"matchDomains,omitempty"`
 
 	Action string `json:"action,omitempty"`
 }
@@ -392,6 +644,89 @@ type CapabilitiesType struct {
 	Action string `json:"action,omitempty"`
 }
 
+// PrivilegeSyscallType Structure
+type PrivilegeSyscallType struct {
+	Severity int      `json:"severity,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Message  string   `json:"message,omitempty"`
+
+	Syscall       string            `json:"syscall"`
+	FromSource    []MatchSourceType `json:"fromSource,omitempty"`
+	HostNamespace bool              `json:"hostNamespace,omitempty"`
+
+	Action string `json:"action,omitempty"`
+}
+
+// PrivilegeType Structure
+type PrivilegeType struct {
+	Severity int      `json:"severity,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Message  string   `json:"message,omitempty"`
+
+	MatchSyscalls []PrivilegeSyscallType `json:"matchSyscalls,omitempty"`
+
+	Action string `json:"action,omitempty"`
+}
+
+// SyscallsSyscallType Structure
+type SyscallsSyscallType struct {
+	Severity int      `json:"severity,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Message  string   `json:"message,omitempty"`
+
+	Syscall string `json:"syscall"`
+
+	Action string `json:"action,omitempty"`
+}
+
+// SyscallsType Structure
+type SyscallsType struct {
+	Severity int      `json:"severity,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Message  string   `json:"message,omitempty"`
+
+	MatchSyscalls []SyscallsSyscallType `json:"matchSyscalls,omitempty"`
+
+	Action string `json:"action,omitempty"`
+}
+
+// SeccompSyscall Structure
+type SeccompSyscall struct {
+	Names    []string `json:"names"`
+	Action   string   `json:"action"`
+	ErrnoRet uint     `json:"errnoRet,omitempty"`
+}
+
+// SeccompProfile Structure (OCI seccomp profile)
+type SeccompProfile struct {
+	DefaultAction string           `json:"defaultAction"`
+	Architectures []string         `json:"architectures,omitempty"`
+	Syscalls      []SeccompSyscall `json:"syscalls,omitempty"`
+}
+
+// KernelOperationType Structure
+type KernelOperationType struct {
+	Severity int      `json:"severity,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Message  string   `json:"message,omitempty"`
+
+	Operation  string            `json:"operation"`
+	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+
+	Action string `json:"action,omitempty"`
+}
+
+// KernelType Structure
+type KernelType struct {
+	Severity int      `json:"severity,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Message  string   `json:"message,omitempty"`
+
+	MatchOperations []KernelOperationType `json:"matchOperations,omitempty"`
+
+	Action string `json:"action,omitempty"`
+}
+
 // MatchVolumeMountType Structure
 type MatchVolumeMountType struct {
 	Severity int      `json:"severity,omitempty"`
@@ -416,22 +751,33 @@ type SELinuxType struct {
 	Action string `json:"action,omitempty"`
 }
 
+// ResponseType Structure
+type ResponseType struct {
+	MinSeverity int      `json:"minSeverity,omitempty"`
+	Actions     []string `json:"actions,omitempty"`
+}
+
 // SecuritySpec Structure
 type SecuritySpec struct {
 	Severity int      `json:"severity"`
 	Tags     []string `json:"tags,omitempty"`
 	Message  string   `json:"message,omitempty"`
 
-	Selector SelectorType `json:"selector"`
+	Selector          SelectorType          `json:"selector"`
+	NamespaceSelector NamespaceSelectorType `json:"namespaceSelector,omitempty"` // only for cluster policies
 
 	Process      ProcessType      `json:"process,omitempty"`
 	File         FileType         `json:"file,omitempty"`
 	Network      NetworkType      `json:"network,omitempty"`
 	Capabilities CapabilitiesType `json:"capabilities,omitempty"`
+	Privilege    PrivilegeType    `json:"privilege,omitempty"`
+	Syscalls     SyscallsType     `json:"syscalls,omitempty"`
 
 	AppArmor string      `json:"apparmor,omitempty"`
 	SELinux  SELinuxType `json:"selinux,omitempty"`
 
+	Response ResponseType `json:"response,omitempty"`
+
 	Action string `json:"action"`
 }
 
@@ -447,8 +793,9 @@ type SecurityPolicy struct {
 
 // NodeSelectorType Structure
 type NodeSelectorType struct {
-	MatchNames  map[string]string `json:"matchNames,omitempty"`
-	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+	MatchNames       map[string]string     `json:"matchNames,omitempty"`
+	MatchLabels      map[string]string     `json:"matchLabels,omitempty"`
+	MatchExpressions []MatchExpressionType `json:"matchExpressions,omitempty"`
 
 	Identities []string `json:"identities,omitempty"` // set during policy update
 }
@@ -465,6 +812,8 @@ type HostSecuritySpec struct {
 	File         FileType         `json:"file,omitempty"`
 	Network      NetworkType      `json:"network,omitempty"`
 	Capabilities CapabilitiesType `json:"capabilities,omitempty"`
+	Privilege    PrivilegeType    `json:"privilege,omitempty"`
+	Kernel       KernelType       `json:"kernel,omitempty"`
 
 	AppArmor string `json:"apparmor,omitempty"`
 
@@ -498,6 +847,7 @@ type PidNode struct {
 
 	Comm     string
 	ExecPath string
+	Args     []string
 
 	Exited     bool
 	ExitedTime time.Time
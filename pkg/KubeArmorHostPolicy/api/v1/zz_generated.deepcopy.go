@@ -47,6 +47,7 @@ func (in *FileDirectoryType) DeepCopyInto(out *FileDirectoryType) {
 		*out = make([]MatchSourceType, len(*in))
 		copy(*out, *in)
 	}
+	in.FromUser.DeepCopyInto(&out.FromUser)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -72,6 +73,7 @@ func (in *FilePathType) DeepCopyInto(out *FilePathType) {
 		*out = make([]MatchSourceType, len(*in))
 		copy(*out, *in)
 	}
+	in.FromUser.DeepCopyInto(&out.FromUser)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -150,6 +152,33 @@ func (in *FileType) DeepCopy() *FileType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KernelType) DeepCopyInto(out *KernelType) {
+	*out = *in
+	if in.MatchOperations != nil {
+		in, out := &in.MatchOperations, &out.MatchOperations
+		*out = make([]MatchKernelOperationType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelType.
+func (in *KernelType) DeepCopy() *KernelType {
+	if in == nil {
+		return nil
+	}
+	out := new(KernelType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeArmorHostPolicy) DeepCopyInto(out *KubeArmorHostPolicy) {
 	*out = *in
@@ -217,6 +246,8 @@ func (in *KubeArmorHostPolicySpec) DeepCopyInto(out *KubeArmorHostPolicySpec) {
 	in.File.DeepCopyInto(&out.File)
 	in.Network.DeepCopyInto(&out.Network)
 	in.Capabilities.DeepCopyInto(&out.Capabilities)
+	in.Kernel.DeepCopyInto(&out.Kernel)
+	in.Privilege.DeepCopyInto(&out.Privilege)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -249,6 +280,21 @@ func (in *KubeArmorHostPolicyStatus) DeepCopy() *KubeArmorHostPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchArgType) DeepCopyInto(out *MatchArgType) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchArgType.
+func (in *MatchArgType) DeepCopy() *MatchArgType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchArgType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MatchCapabilitiesType) DeepCopyInto(out *MatchCapabilitiesType) {
 	*out = *in
@@ -274,6 +320,134 @@ func (in *MatchCapabilitiesType) DeepCopy() *MatchCapabilitiesType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchExpressionType) DeepCopyInto(out *MatchExpressionType) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchExpressionType.
+func (in *MatchExpressionType) DeepCopy() *MatchExpressionType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchExpressionType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchKernelOperationType) DeepCopyInto(out *MatchKernelOperationType) {
+	*out = *in
+	if in.FromSource != nil {
+		in, out := &in.FromSource, &out.FromSource
+		*out = make([]MatchSourceType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchKernelOperationType.
+func (in *MatchKernelOperationType) DeepCopy() *MatchKernelOperationType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchKernelOperationType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchNetworkDomainType) DeepCopyInto(out *MatchNetworkDomainType) {
+	*out = *in
+	if in.FromSource != nil {
+		in, out := &in.FromSource, &out.FromSource
+		*out = make([]MatchSourceType, len(*in))
+		copy(*out, *in)
+	}
+	in.FromUser.DeepCopyInto(&out.FromUser)
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchNetworkDomainType.
+func (in *MatchNetworkDomainType) DeepCopy() *MatchNetworkDomainType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchNetworkDomainType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchNetworkIPType) DeepCopyInto(out *MatchNetworkIPType) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]MatchNetworkPortNumberType, len(*in))
+		copy(*out, *in)
+	}
+	if in.FromSource != nil {
+		in, out := &in.FromSource, &out.FromSource
+		*out = make([]MatchSourceType, len(*in))
+		copy(*out, *in)
+	}
+	in.FromUser.DeepCopyInto(&out.FromUser)
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchNetworkIPType.
+func (in *MatchNetworkIPType) DeepCopy() *MatchNetworkIPType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchNetworkIPType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchNetworkPortType) DeepCopyInto(out *MatchNetworkPortType) {
+	*out = *in
+	if in.FromSource != nil {
+		in, out := &in.FromSource, &out.FromSource
+		*out = make([]MatchSourceType, len(*in))
+		copy(*out, *in)
+	}
+	in.FromUser.DeepCopyInto(&out.FromUser)
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchNetworkPortType.
+func (in *MatchNetworkPortType) DeepCopy() *MatchNetworkPortType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchNetworkPortType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MatchNetworkProtocolType) DeepCopyInto(out *MatchNetworkProtocolType) {
 	*out = *in
@@ -282,6 +456,7 @@ func (in *MatchNetworkProtocolType) DeepCopyInto(out *MatchNetworkProtocolType)
 		*out = make([]MatchSourceType, len(*in))
 		copy(*out, *in)
 	}
+	in.FromUser.DeepCopyInto(&out.FromUser)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -299,6 +474,31 @@ func (in *MatchNetworkProtocolType) DeepCopy() *MatchNetworkProtocolType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchPrivilegeSyscallType) DeepCopyInto(out *MatchPrivilegeSyscallType) {
+	*out = *in
+	if in.FromSource != nil {
+		in, out := &in.FromSource, &out.FromSource
+		*out = make([]MatchSourceType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchPrivilegeSyscallType.
+func (in *MatchPrivilegeSyscallType) DeepCopy() *MatchPrivilegeSyscallType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchPrivilegeSyscallType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MatchSourceType) DeepCopyInto(out *MatchSourceType) {
 	*out = *in
@@ -314,6 +514,31 @@ func (in *MatchSourceType) DeepCopy() *MatchSourceType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchUserType) DeepCopyInto(out *MatchUserType) {
+	*out = *in
+	if in.MatchUIDs != nil {
+		in, out := &in.MatchUIDs, &out.MatchUIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeUIDs != nil {
+		in, out := &in.ExcludeUIDs, &out.ExcludeUIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchUserType.
+func (in *MatchUserType) DeepCopy() *MatchUserType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchUserType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkType) DeepCopyInto(out *NetworkType) {
 	*out = *in
@@ -324,6 +549,27 @@ func (in *NetworkType) DeepCopyInto(out *NetworkType) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MatchIPs != nil {
+		in, out := &in.MatchIPs, &out.MatchIPs
+		*out = make([]MatchNetworkIPType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MatchPorts != nil {
+		in, out := &in.MatchPorts, &out.MatchPorts
+		*out = make([]MatchNetworkPortType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MatchDomains != nil {
+		in, out := &in.MatchDomains, &out.MatchDomains
+		*out = make([]MatchNetworkDomainType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -351,6 +597,13 @@ func (in *NodeSelectorType) DeepCopyInto(out *NodeSelectorType) {
 			(*out)[key] = val
 		}
 	}
+	if in.MatchExpressions != nil {
+		in, out := &in.MatchExpressions, &out.MatchExpressions
+		*out = make([]MatchExpressionType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSelectorType.
@@ -363,14 +616,47 @@ func (in *NodeSelectorType) DeepCopy() *NodeSelectorType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivilegeType) DeepCopyInto(out *PrivilegeType) {
+	*out = *in
+	if in.MatchSyscalls != nil {
+		in, out := &in.MatchSyscalls, &out.MatchSyscalls
+		*out = make([]MatchPrivilegeSyscallType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivilegeType.
+func (in *PrivilegeType) DeepCopy() *PrivilegeType {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivilegeType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProcessDirectoryType) DeepCopyInto(out *ProcessDirectoryType) {
 	*out = *in
+	if in.MatchArgs != nil {
+		in, out := &in.MatchArgs, &out.MatchArgs
+		*out = make([]MatchArgType, len(*in))
+		copy(*out, *in)
+	}
 	if in.FromSource != nil {
 		in, out := &in.FromSource, &out.FromSource
 		*out = make([]MatchSourceType, len(*in))
 		copy(*out, *in)
 	}
+	in.FromUser.DeepCopyInto(&out.FromUser)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -388,14 +674,45 @@ func (in *ProcessDirectoryType) DeepCopy() *ProcessDirectoryType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessFilelessType) DeepCopyInto(out *ProcessFilelessType) {
+	*out = *in
+	if in.FromSource != nil {
+		in, out := &in.FromSource, &out.FromSource
+		*out = make([]MatchSourceType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessFilelessType.
+func (in *ProcessFilelessType) DeepCopy() *ProcessFilelessType {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessFilelessType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProcessPathType) DeepCopyInto(out *ProcessPathType) {
 	*out = *in
+	if in.MatchArgs != nil {
+		in, out := &in.MatchArgs, &out.MatchArgs
+		*out = make([]MatchArgType, len(*in))
+		copy(*out, *in)
+	}
 	if in.FromSource != nil {
 		in, out := &in.FromSource, &out.FromSource
 		*out = make([]MatchSourceType, len(*in))
 		copy(*out, *in)
 	}
+	in.FromUser.DeepCopyInto(&out.FromUser)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -457,6 +774,13 @@ func (in *ProcessType) DeepCopyInto(out *ProcessType) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MatchFileless != nil {
+		in, out := &in.MatchFileless, &out.MatchFileless
+		*out = make([]ProcessFilelessType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -43,6 +43,11 @@ func (r *KubeArmorHostPolicyReconciler) Reconcile(req ctrl.Request) (ctrl.Result
 
 	// Validate KubeArmorPolicy
 	// if there are some issues in the policy the delete the policy and return failure code
+	policyErr = validateNodeSelectorSchema(policy, req)
+	if policyErr != nil {
+		goto POLICYERROR
+	}
+
 	policyErr = validateProcessSchema(policy, req)
 	if policyErr != nil {
 		goto POLICYERROR
@@ -87,6 +92,24 @@ func (r *KubeArmorHostPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error
 		Complete(r)
 }
 
+func validateNodeSelectorSchema(policy *securityv1.KubeArmorHostPolicy, req ctrl.Request) error {
+	var policyErr error
+	for _, matchExpression := range policy.Spec.NodeSelector.MatchExpressions {
+		if matchExpression.Operator == "In" || matchExpression.Operator == "NotIn" {
+			if len(matchExpression.Values) == 0 {
+				policyErr = fmt.Errorf("%s requires values in matchExpressions %v", matchExpression.Operator, req.NamespacedName)
+				return policyErr
+			}
+		} else {
+			if len(matchExpression.Values) > 0 {
+				policyErr = fmt.Errorf("%s does not take values in matchExpressions %v", matchExpression.Operator, req.NamespacedName)
+				return policyErr
+			}
+		}
+	}
+	return policyErr
+}
+
 func validateProcessSchema(policy *securityv1.KubeArmorHostPolicy, req ctrl.Request) error {
 	var policyErr error
 	for _, matchPaths := range policy.Spec.Process.MatchPaths {
@@ -0,0 +1,49 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type NamespaceSelectorType struct {
+	// +kubebuilder:validation:optional
+	MatchNames []string `json:"matchNames,omitempty"`
+	// +kubebuilder:validation:optional
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+}
+
+// KubeArmorClusterPolicySpec defines the desired state of KubeArmorClusterPolicy
+type KubeArmorClusterPolicySpec struct {
+	// +kubebuilder:validation:optional
+	NamespaceSelector NamespaceSelectorType `json:"namespaceSelector,omitempty"`
+
+	KubeArmorPolicySpec `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// KubeArmorClusterPolicy is the Schema for the kubearmorclusterpolicies API
+// +kubebuilder:resource:scope=Cluster,shortName=kcsp
+// +kubebuilder:subresource:status
+type KubeArmorClusterPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KubeArmorClusterPolicySpec `json:"spec,omitempty"`
+	Status KubeArmorPolicyStatus      `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KubeArmorClusterPolicyList contains a list of KubeArmorClusterPolicy
+type KubeArmorClusterPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KubeArmorClusterPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KubeArmorClusterPolicy{}, &KubeArmorClusterPolicyList{})
+}
@@ -14,8 +14,27 @@ import (
 // +kubebuilder:validation:Maximum:=10
 type SeverityType int
 
+// +kubebuilder:validation:Enum=In;NotIn;Exists;DoesNotExist
+type MatchExpressionOperatorType string
+
+// +kubebuilder:validation:Enum=Label;Isolate;Evict
+type QuarantineActionType string
+
+type MatchExpressionType struct {
+	Key      string                      `json:"key"`
+	Operator MatchExpressionOperatorType `json:"operator"`
+
+	// +kubebuilder:validation:optional
+	Values []string `json:"values,omitempty"`
+}
+
 type SelectorType struct {
 	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+	// +kubebuilder:validation:optional
+	MatchExpressions []MatchExpressionType `json:"matchExpressions,omitempty"`
+
+	// +kubebuilder:validation:optional
+	Containers []string `json:"containers,omitempty"`
 }
 
 // +kubebuilder:validation:Pattern=^\/([A-z0-9-_.]+\/)*([A-z0-9-_.]+)$
@@ -32,14 +51,40 @@ type MatchSourceType struct {
 	Recursive bool `json:"recursive,omitempty"`
 }
 
+type MatchUserType struct {
+	// +kubebuilder:validation:optional
+	MatchUIDs []int `json:"matchUIDs,omitempty"`
+	// +kubebuilder:validation:optional
+	ExcludeUIDs []int `json:"excludeUIDs,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Exact;Glob;Regexp
+type ArgMatchType string
+
+type MatchArgType struct {
+	Arg string `json:"arg"`
+
+	// +kubebuilder:validation:Optional
+	Type ArgMatchType `json:"type,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Index int `json:"index,omitempty"`
+}
+
 type ProcessPathType struct {
 	Path MatchPathType `json:"path"`
 
 	// +kubebuilder:validation:Optional
 	OwnerOnly bool `json:"ownerOnly,omitempty"`
 
+	// +kubebuilder:validation:optional
+	MatchArgs []MatchArgType `json:"matchArgs,omitempty"`
+
 	// +kubebuilder:validation:optional
 	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+	// +kubebuilder:validation:optional
+	FromUser MatchUserType `json:"fromUser,omitempty"`
 
 	// +kubebuilder:validation:optional
 	Severity SeverityType `json:"severity,omitempty"`
@@ -59,8 +104,13 @@ type ProcessDirectoryType struct {
 	// +kubebuilder:validation:Optional
 	OwnerOnly bool `json:"ownerOnly,omitempty"`
 
+	// +kubebuilder:validation:optional
+	MatchArgs []MatchArgType `json:"matchArgs,omitempty"`
+
 	// +kubebuilder:validation:optional
 	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+	// +kubebuilder:validation:optional
+	FromUser MatchUserType `json:"fromUser,omitempty"`
 
 	// +kubebuilder:validation:optional
 	Severity SeverityType `json:"severity,omitempty"`
@@ -88,10 +138,30 @@ type ProcessPatternType struct {
 	Action ActionType `json:"action,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=memfd;deleted
+type FilelessStringType string
+
+type ProcessFilelessType struct {
+	Type FilelessStringType `json:"type"`
+
+	// +kubebuilder:validation:optional
+	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+
+	// +kubebuilder:validation:optional
+	Severity SeverityType `json:"severity,omitempty"`
+	// +kubebuilder:validation:optional
+	Tags []string `json:"tags,omitempty"`
+	// +kubebuilder:validation:optional
+	Message string `json:"message,omitempty"`
+	// +kubebuilder:validation:optional
+	Action ActionType `json:"action,omitempty"`
+}
+
 type ProcessType struct {
 	MatchPaths       []ProcessPathType      `json:"matchPaths,omitempty"`
 	MatchDirectories []ProcessDirectoryType `json:"matchDirectories,omitempty"`
 	MatchPatterns    []ProcessPatternType   `json:"matchPatterns,omitempty"`
+	MatchFileless    []ProcessFilelessType  `json:"matchFileless,omitempty"`
 
 	// +kubebuilder:validation:optional
 	Severity SeverityType `json:"severity,omitempty"`
@@ -113,6 +183,8 @@ type FilePathType struct {
 
 	// +kubebuilder:validation:optional
 	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+	// +kubebuilder:validation:optional
+	FromUser MatchUserType `json:"fromUser,omitempty"`
 
 	// +kubebuilder:validation:optional
 	Severity SeverityType `json:"severity,omitempty"`
@@ -136,6 +208,8 @@ type FileDirectoryType struct {
 
 	// +kubebuilder:validation:optional
 	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+	// +kubebuilder:validation:optional
+	FromUser MatchUserType `json:"fromUser,omitempty"`
 
 	// +kubebuilder:validation:optional
 	Severity SeverityType `json:"severity,omitempty"`
@@ -188,6 +262,75 @@ type MatchNetworkProtocolType struct {
 
 	// +kubebuilder:validation:optional
 	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+	// +kubebuilder:validation:optional
+	FromUser MatchUserType `json:"fromUser,omitempty"`
+
+	// +kubebuilder:validation:optional
+	Severity SeverityType `json:"severity,omitempty"`
+	// +kubebuilder:validation:optional
+	Tags []string `json:"tags,omitempty"`
+	// +kubebuilder:validation:optional
+	Message string `json:"message,omitempty"`
+	// +kubebuilder:validation:optional
+	Action ActionType `json:"action,omitempty"`
+}
+
+// +kubebuilder:validation:Pattern=^(\*\.)?([a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?\.?$
+type MatchNetworkDomainStringType string
+
+type MatchNetworkDomainType struct {
+	Domain MatchNetworkDomainStringType `json:"domain"`
+
+	// +kubebuilder:validation:optional
+	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+	// +kubebuilder:validation:optional
+	FromUser MatchUserType `json:"fromUser,omitempty"`
+
+	// +kubebuilder:validation:optional
+	Severity SeverityType `json:"severity,omitempty"`
+	// +kubebuilder:validation:optional
+	Tags []string `json:"tags,omitempty"`
+	// +kubebuilder:validation:optional
+	Message string `json:"message,omitempty"`
+	// +kubebuilder:validation:optional
+	Action ActionType `json:"action,omitempty"`
+}
+
+// +kubebuilder:validation:Pattern=^([0-9]{1,3}\.){3}[0-9]{1,3}(\/([0-9]|[1-2][0-9]|3[0-2]))?$
+type MatchNetworkIPStringType string
+
+// +kubebuilder:validation:Minimum:=1
+// +kubebuilder:validation:Maximum:=65535
+type MatchNetworkPortNumberType int
+
+type MatchNetworkIPType struct {
+	IP MatchNetworkIPStringType `json:"ip"`
+
+	// +kubebuilder:validation:optional
+	Ports []MatchNetworkPortNumberType `json:"ports,omitempty"`
+
+	// +kubebuilder:validation:optional
+	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+	// +kubebuilder:validation:optional
+	FromUser MatchUserType `json:"fromUser,omitempty"`
+
+	// +kubebuilder:validation:optional
+	Severity SeverityType `json:"severity,omitempty"`
+	// +kubebuilder:validation:optional
+	Tags []string `json:"tags,omitempty"`
+	// +kubebuilder:validation:optional
+	Message string `json:"message,omitempty"`
+	// +kubebuilder:validation:optional
+	Action ActionType `json:"action,omitempty"`
+}
+
+type MatchNetworkPortType struct {
+	Port MatchNetworkPortNumberType `json:"port"`
+
+	// +kubebuilder:validation:optional
+	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+	// +kubebuilder:validation:optional
+	FromUser MatchUserType `json:"fromUser,omitempty"`
 
 	// +kubebuilder:validation:optional
 	Severity SeverityType `json:"severity,omitempty"`
@@ -200,7 +343,14 @@ type MatchNetworkProtocolType struct {
 }
 
 type NetworkType struct {
-	MatchProtocols []MatchNetworkProtocolType `json:"matchProtocols"`
+	// +kubebuilder:validation:optional
+	MatchProtocols []MatchNetworkProtocolType `json:"matchProtocols,omitempty"`
+	// +kubebuilder:validation:optional
+	MatchIPs []MatchNetworkIPType `json:"matchIPs,omitempty"`
+	// +kubebuilder:validation:optional
+	MatchPorts []MatchNetworkPortType `json:"matchPorts,omitempty"`
+	// +kubebuilder:validation:optional
+	MatchDomains []MatchNetworkDomainType `json:"matchDomains,omitempty"`
 
 	// +kubebuilder:validation:optional
 	Severity SeverityType `json:"severity,omitempty"`
@@ -262,6 +412,12 @@ type MatchVolumeMountType struct {
 	Action ActionType `json:"action,omitempty"`
 }
 
+type ResponseType struct {
+	MinSeverity SeverityType `json:"minSeverity"`
+	// +kubebuilder:validation:MinItems=1
+	Actions []QuarantineActionType `json:"actions"`
+}
+
 type SELinuxType struct {
 	MatchVolumeMounts []MatchVolumeMountType `json:"matchVolumeMounts"`
 
@@ -275,7 +431,73 @@ type SELinuxType struct {
 	Action ActionType `json:"action,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=setuid;setgid;setreuid;setregid;setresuid;setresgid;setns;unshare;mount;ptrace
+type PrivilegeSyscallStringType string
+
+type MatchPrivilegeSyscallType struct {
+	Syscall PrivilegeSyscallStringType `json:"syscall"`
+
+	// +kubebuilder:validation:optional
+	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+	// +kubebuilder:validation:optional
+	HostNamespace bool `json:"hostNamespace,omitempty"`
+
+	// +kubebuilder:validation:optional
+	Severity SeverityType `json:"severity,omitempty"`
+	// +kubebuilder:validation:optional
+	Tags []string `json:"tags,omitempty"`
+	// +kubebuilder:validation:optional
+	Message string `json:"message,omitempty"`
+	// +kubebuilder:validation:optional
+	Action ActionType `json:"action,omitempty"`
+}
+
+type PrivilegeType struct {
+	MatchSyscalls []MatchPrivilegeSyscallType `json:"matchSyscalls"`
+
+	// +kubebuilder:validation:optional
+	Severity SeverityType `json:"severity,omitempty"`
+	// +kubebuilder:validation:optional
+	Tags []string `json:"tags,omitempty"`
+	// +kubebuilder:validation:optional
+	Message string `json:"message,omitempty"`
+	// +kubebuilder:validation:optional
+	Action ActionType `json:"action,omitempty"`
+}
+
+// +kubebuilder:validation:Pattern=^[a-z0-9_]+$
+type SyscallStringType string
+
 // +kubebuilder:validation:Enum=Allow;Audit;Block
+type SyscallActionType string
+
+type MatchSyscallType struct {
+	Syscall SyscallStringType `json:"syscall"`
+
+	// +kubebuilder:validation:optional
+	Severity SeverityType `json:"severity,omitempty"`
+	// +kubebuilder:validation:optional
+	Tags []string `json:"tags,omitempty"`
+	// +kubebuilder:validation:optional
+	Message string `json:"message,omitempty"`
+	// +kubebuilder:validation:optional
+	Action SyscallActionType `json:"action,omitempty"`
+}
+
+type SyscallsType struct {
+	MatchSyscalls []MatchSyscallType `json:"matchSyscalls"`
+
+	// +kubebuilder:validation:optional
+	Severity SeverityType `json:"severity,omitempty"`
+	// +kubebuilder:validation:optional
+	Tags []string `json:"tags,omitempty"`
+	// +kubebuilder:validation:optional
+	Message string `json:"message,omitempty"`
+	// +kubebuilder:validation:optional
+	Action SyscallActionType `json:"action,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Allow;Audit;Block;Kill;Stop
 type ActionType string
 
 // KubeArmorPolicySpec defines the desired state of KubeArmorPolicy
@@ -289,10 +511,17 @@ type KubeArmorPolicySpec struct {
 	File         FileType         `json:"file,omitempty"`
 	Network      NetworkType      `json:"network,omitempty"`
 	Capabilities CapabilitiesType `json:"capabilities,omitempty"`
+	Privilege    PrivilegeType    `json:"privilege,omitempty"`
+
+	// +kubebuilder:validation:optional
+	Syscalls SyscallsType `json:"syscalls,omitempty"`
 
 	AppArmor string      `json:"apparmor,omitempty"`
 	SELinux  SELinuxType `json:"selinux,omitempty"`
 
+	// +kubebuilder:validation:optional
+	Response ResponseType `json:"response,omitempty"`
+
 	// +kubebuilder:validation:optional
 	Severity SeverityType `json:"severity,omitempty"`
 	// +kubebuilder:validation:optional
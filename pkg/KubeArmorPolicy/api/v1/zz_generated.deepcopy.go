@@ -47,6 +47,7 @@ func (in *FileDirectoryType) DeepCopyInto(out *FileDirectoryType) {
 		*out = make([]MatchSourceType, len(*in))
 		copy(*out, *in)
 	}
+	in.FromUser.DeepCopyInto(&out.FromUser)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -72,6 +73,7 @@ func (in *FilePathType) DeepCopyInto(out *FilePathType) {
 		*out = make([]MatchSourceType, len(*in))
 		copy(*out, *in)
 	}
+	in.FromUser.DeepCopyInto(&out.FromUser)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -150,6 +152,82 @@ func (in *FileType) DeepCopy() *FileType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeArmorClusterPolicy) DeepCopyInto(out *KubeArmorClusterPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeArmorClusterPolicy.
+func (in *KubeArmorClusterPolicy) DeepCopy() *KubeArmorClusterPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeArmorClusterPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubeArmorClusterPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeArmorClusterPolicyList) DeepCopyInto(out *KubeArmorClusterPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KubeArmorClusterPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeArmorClusterPolicyList.
+func (in *KubeArmorClusterPolicyList) DeepCopy() *KubeArmorClusterPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeArmorClusterPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubeArmorClusterPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeArmorClusterPolicySpec) DeepCopyInto(out *KubeArmorClusterPolicySpec) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	in.KubeArmorPolicySpec.DeepCopyInto(&out.KubeArmorPolicySpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeArmorClusterPolicySpec.
+func (in *KubeArmorClusterPolicySpec) DeepCopy() *KubeArmorClusterPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeArmorClusterPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeArmorPolicy) DeepCopyInto(out *KubeArmorPolicy) {
 	*out = *in
@@ -217,7 +295,10 @@ func (in *KubeArmorPolicySpec) DeepCopyInto(out *KubeArmorPolicySpec) {
 	in.File.DeepCopyInto(&out.File)
 	in.Network.DeepCopyInto(&out.Network)
 	in.Capabilities.DeepCopyInto(&out.Capabilities)
+	in.Privilege.DeepCopyInto(&out.Privilege)
+	in.Syscalls.DeepCopyInto(&out.Syscalls)
 	in.SELinux.DeepCopyInto(&out.SELinux)
+	in.Response.DeepCopyInto(&out.Response)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -250,6 +331,21 @@ func (in *KubeArmorPolicyStatus) DeepCopy() *KubeArmorPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchArgType) DeepCopyInto(out *MatchArgType) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchArgType.
+func (in *MatchArgType) DeepCopy() *MatchArgType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchArgType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MatchCapabilitiesType) DeepCopyInto(out *MatchCapabilitiesType) {
 	*out = *in
@@ -275,6 +371,109 @@ func (in *MatchCapabilitiesType) DeepCopy() *MatchCapabilitiesType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchExpressionType) DeepCopyInto(out *MatchExpressionType) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchExpressionType.
+func (in *MatchExpressionType) DeepCopy() *MatchExpressionType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchExpressionType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchNetworkDomainType) DeepCopyInto(out *MatchNetworkDomainType) {
+	*out = *in
+	if in.FromSource != nil {
+		in, out := &in.FromSource, &out.FromSource
+		*out = make([]MatchSourceType, len(*in))
+		copy(*out, *in)
+	}
+	in.FromUser.DeepCopyInto(&out.FromUser)
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchNetworkDomainType.
+func (in *MatchNetworkDomainType) DeepCopy() *MatchNetworkDomainType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchNetworkDomainType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchNetworkIPType) DeepCopyInto(out *MatchNetworkIPType) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]MatchNetworkPortNumberType, len(*in))
+		copy(*out, *in)
+	}
+	if in.FromSource != nil {
+		in, out := &in.FromSource, &out.FromSource
+		*out = make([]MatchSourceType, len(*in))
+		copy(*out, *in)
+	}
+	in.FromUser.DeepCopyInto(&out.FromUser)
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchNetworkIPType.
+func (in *MatchNetworkIPType) DeepCopy() *MatchNetworkIPType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchNetworkIPType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchNetworkPortType) DeepCopyInto(out *MatchNetworkPortType) {
+	*out = *in
+	if in.FromSource != nil {
+		in, out := &in.FromSource, &out.FromSource
+		*out = make([]MatchSourceType, len(*in))
+		copy(*out, *in)
+	}
+	in.FromUser.DeepCopyInto(&out.FromUser)
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchNetworkPortType.
+func (in *MatchNetworkPortType) DeepCopy() *MatchNetworkPortType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchNetworkPortType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MatchNetworkProtocolType) DeepCopyInto(out *MatchNetworkProtocolType) {
 	*out = *in
@@ -283,6 +482,7 @@ func (in *MatchNetworkProtocolType) DeepCopyInto(out *MatchNetworkProtocolType)
 		*out = make([]MatchSourceType, len(*in))
 		copy(*out, *in)
 	}
+	in.FromUser.DeepCopyInto(&out.FromUser)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -300,6 +500,31 @@ func (in *MatchNetworkProtocolType) DeepCopy() *MatchNetworkProtocolType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchPrivilegeSyscallType) DeepCopyInto(out *MatchPrivilegeSyscallType) {
+	*out = *in
+	if in.FromSource != nil {
+		in, out := &in.FromSource, &out.FromSource
+		*out = make([]MatchSourceType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchPrivilegeSyscallType.
+func (in *MatchPrivilegeSyscallType) DeepCopy() *MatchPrivilegeSyscallType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchPrivilegeSyscallType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MatchSourceType) DeepCopyInto(out *MatchSourceType) {
 	*out = *in
@@ -315,6 +540,51 @@ func (in *MatchSourceType) DeepCopy() *MatchSourceType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchSyscallType) DeepCopyInto(out *MatchSyscallType) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchSyscallType.
+func (in *MatchSyscallType) DeepCopy() *MatchSyscallType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchSyscallType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchUserType) DeepCopyInto(out *MatchUserType) {
+	*out = *in
+	if in.MatchUIDs != nil {
+		in, out := &in.MatchUIDs, &out.MatchUIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeUIDs != nil {
+		in, out := &in.ExcludeUIDs, &out.ExcludeUIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchUserType.
+func (in *MatchUserType) DeepCopy() *MatchUserType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchUserType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MatchVolumeMountType) DeepCopyInto(out *MatchVolumeMountType) {
 	*out = *in
@@ -335,6 +605,33 @@ func (in *MatchVolumeMountType) DeepCopy() *MatchVolumeMountType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceSelectorType) DeepCopyInto(out *NamespaceSelectorType) {
+	*out = *in
+	if in.MatchNames != nil {
+		in, out := &in.MatchNames, &out.MatchNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSelectorType.
+func (in *NamespaceSelectorType) DeepCopy() *NamespaceSelectorType {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceSelectorType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkType) DeepCopyInto(out *NetworkType) {
 	*out = *in
@@ -345,6 +642,27 @@ func (in *NetworkType) DeepCopyInto(out *NetworkType) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MatchIPs != nil {
+		in, out := &in.MatchIPs, &out.MatchIPs
+		*out = make([]MatchNetworkIPType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MatchPorts != nil {
+		in, out := &in.MatchPorts, &out.MatchPorts
+		*out = make([]MatchNetworkPortType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MatchDomains != nil {
+		in, out := &in.MatchDomains, &out.MatchDomains
+		*out = make([]MatchNetworkDomainType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -362,14 +680,47 @@ func (in *NetworkType) DeepCopy() *NetworkType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivilegeType) DeepCopyInto(out *PrivilegeType) {
+	*out = *in
+	if in.MatchSyscalls != nil {
+		in, out := &in.MatchSyscalls, &out.MatchSyscalls
+		*out = make([]MatchPrivilegeSyscallType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivilegeType.
+func (in *PrivilegeType) DeepCopy() *PrivilegeType {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivilegeType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProcessDirectoryType) DeepCopyInto(out *ProcessDirectoryType) {
 	*out = *in
+	if in.MatchArgs != nil {
+		in, out := &in.MatchArgs, &out.MatchArgs
+		*out = make([]MatchArgType, len(*in))
+		copy(*out, *in)
+	}
 	if in.FromSource != nil {
 		in, out := &in.FromSource, &out.FromSource
 		*out = make([]MatchSourceType, len(*in))
 		copy(*out, *in)
 	}
+	in.FromUser.DeepCopyInto(&out.FromUser)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -387,14 +738,45 @@ func (in *ProcessDirectoryType) DeepCopy() *ProcessDirectoryType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessFilelessType) DeepCopyInto(out *ProcessFilelessType) {
+	*out = *in
+	if in.FromSource != nil {
+		in, out := &in.FromSource, &out.FromSource
+		*out = make([]MatchSourceType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessFilelessType.
+func (in *ProcessFilelessType) DeepCopy() *ProcessFilelessType {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessFilelessType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProcessPathType) DeepCopyInto(out *ProcessPathType) {
 	*out = *in
+	if in.MatchArgs != nil {
+		in, out := &in.MatchArgs, &out.MatchArgs
+		*out = make([]MatchArgType, len(*in))
+		copy(*out, *in)
+	}
 	if in.FromSource != nil {
 		in, out := &in.FromSource, &out.FromSource
 		*out = make([]MatchSourceType, len(*in))
 		copy(*out, *in)
 	}
+	in.FromUser.DeepCopyInto(&out.FromUser)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -456,6 +838,13 @@ func (in *ProcessType) DeepCopyInto(out *ProcessType) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MatchFileless != nil {
+		in, out := &in.MatchFileless, &out.MatchFileless
+		*out = make([]ProcessFilelessType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -473,6 +862,26 @@ func (in *ProcessType) DeepCopy() *ProcessType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseType) DeepCopyInto(out *ResponseType) {
+	*out = *in
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]QuarantineActionType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseType.
+func (in *ResponseType) DeepCopy() *ResponseType {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SELinuxType) DeepCopyInto(out *SELinuxType) {
 	*out = *in
@@ -510,6 +919,18 @@ func (in *SelectorType) DeepCopyInto(out *SelectorType) {
 			(*out)[key] = val
 		}
 	}
+	if in.MatchExpressions != nil {
+		in, out := &in.MatchExpressions, &out.MatchExpressions
+		*out = make([]MatchExpressionType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorType.
@@ -521,3 +942,30 @@ func (in *SelectorType) DeepCopy() *SelectorType {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyscallsType) DeepCopyInto(out *SyscallsType) {
+	*out = *in
+	if in.MatchSyscalls != nil {
+		in, out := &in.MatchSyscalls, &out.MatchSyscalls
+		*out = make([]MatchSyscallType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyscallsType.
+func (in *SyscallsType) DeepCopy() *SyscallsType {
+	if in == nil {
+		return nil
+	}
+	out := new(SyscallsType)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,113 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	securityv1 "github.com/kubearmor/KubeArmor/pkg/KubeArmorPolicy/api/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// KubeArmorClusterPolicyReconciler reconciles a KubeArmorClusterPolicy object
+type KubeArmorClusterPolicyReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=security.kubearmor.com,resources=kubearmorclusterpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=security.kubearmor.com,resources=kubearmorclusterpolicies/status,verbs=get;update;patch
+
+func (r *KubeArmorClusterPolicyReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("kubearmorclusterpolicy", req.NamespacedName)
+
+	policy := &securityv1.KubeArmorClusterPolicy{}
+
+	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		return ctrl.Result{}, err
+	}
+
+	var policyErr error
+
+	// Validate KubeArmorClusterPolicy
+	// if there are some issues in the policy the delete the policy and return failure code
+	policyErr = validateNamespaceSelector(policy, req)
+	if policyErr != nil {
+		goto POLICYERROR
+	}
+
+	policyErr = validateSelectorSchema(&policy.Spec.KubeArmorPolicySpec, req)
+	if policyErr != nil {
+		goto POLICYERROR
+	}
+
+	policyErr = validateProcessSchema(&policy.Spec.KubeArmorPolicySpec, req)
+	if policyErr != nil {
+		goto POLICYERROR
+	}
+
+	policyErr = validateFileSchema(&policy.Spec.KubeArmorPolicySpec, req)
+	if policyErr != nil {
+		goto POLICYERROR
+	}
+
+	policyErr = validateNetworkSchema(&policy.Spec.KubeArmorPolicySpec, req)
+	if policyErr != nil {
+		goto POLICYERROR
+	}
+
+	policyErr = validateCapabilitiesSchema(&policy.Spec.KubeArmorPolicySpec, req)
+	if policyErr != nil {
+		goto POLICYERROR
+	}
+
+POLICYERROR:
+	if policyErr != nil {
+		// Update PolicyStatus
+		policy.Status.PolicyStatus = "Not OK"
+		err := r.Status().Update(ctx, policy)
+		log.Info("Invalid KubeArmorClusterPolicy")
+		return ctrl.Result{}, err
+	}
+
+	// Update PolicyStatus
+	policy.Status.PolicyStatus = "OK"
+	err := r.Status().Update(ctx, policy)
+	log.Info("Fetched KubeArmorClusterPolicy")
+	return ctrl.Result{}, err
+}
+
+func (r *KubeArmorClusterPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&securityv1.KubeArmorClusterPolicy{}).
+		Complete(r)
+}
+
+func validateNamespaceSelector(policy *securityv1.KubeArmorClusterPolicy, req ctrl.Request) error {
+	var policyErr error
+	for _, namespaceName := range policy.Spec.NamespaceSelector.MatchNames {
+		if namespaceName == "" {
+			policyErr = fmt.Errorf("namespaceSelector has an empty namespace name %v", req.NamespacedName)
+			return policyErr
+		}
+	}
+	for key := range policy.Spec.NamespaceSelector.MatchLabels {
+		if key == "" {
+			policyErr = fmt.Errorf("namespaceSelector has an empty label key %v", req.NamespacedName)
+			return policyErr
+		}
+	}
+	return policyErr
+}
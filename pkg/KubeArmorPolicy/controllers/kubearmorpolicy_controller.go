@@ -43,22 +43,27 @@ func (r *KubeArmorPolicyReconciler) Reconcile(req ctrl.Request) (ctrl.Result, er
 
 	// Validate KubeArmorPolicy
 	// if there are some issues in the policy the delete the policy and return failure code
-	policyErr = validateProcessSchema(policy, req)
+	policyErr = validateSelectorSchema(&policy.Spec, req)
 	if policyErr != nil {
 		goto POLICYERROR
 	}
 
-	policyErr = validateFileSchema(policy, req)
+	policyErr = validateProcessSchema(&policy.Spec, req)
 	if policyErr != nil {
 		goto POLICYERROR
 	}
 
-	policyErr = validateNetworkSchema(policy, req)
+	policyErr = validateFileSchema(&policy.Spec, req)
 	if policyErr != nil {
 		goto POLICYERROR
 	}
 
-	policyErr = validateCapabilitiesSchema(policy, req)
+	policyErr = validateNetworkSchema(&policy.Spec, req)
+	if policyErr != nil {
+		goto POLICYERROR
+	}
+
+	policyErr = validateCapabilitiesSchema(&policy.Spec, req)
 	if policyErr != nil {
 		goto POLICYERROR
 	}
@@ -87,10 +92,28 @@ func (r *KubeArmorPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-func validateProcessSchema(policy *securityv1.KubeArmorPolicy, req ctrl.Request) error {
+func validateSelectorSchema(spec *securityv1.KubeArmorPolicySpec, req ctrl.Request) error {
+	var policyErr error
+	for _, matchExpression := range spec.Selector.MatchExpressions {
+		if matchExpression.Operator == "In" || matchExpression.Operator == "NotIn" {
+			if len(matchExpression.Values) == 0 {
+				policyErr = fmt.Errorf("%s requires values in matchExpressions %v", matchExpression.Operator, req.NamespacedName)
+				return policyErr
+			}
+		} else {
+			if len(matchExpression.Values) > 0 {
+				policyErr = fmt.Errorf("%s does not take values in matchExpressions %v", matchExpression.Operator, req.NamespacedName)
+				return policyErr
+			}
+		}
+	}
+	return policyErr
+}
+
+func validateProcessSchema(spec *securityv1.KubeArmorPolicySpec, req ctrl.Request) error {
 	var policyErr error
-	for _, matchPaths := range policy.Spec.Process.MatchPaths {
-		if policy.Spec.Action != "Allow" {
+	for _, matchPaths := range spec.Process.MatchPaths {
+		if spec.Action != "Allow" {
 			if matchPaths.OwnerOnly {
 				policyErr = fmt.Errorf("ownerOnly works with the Allow action %v", req.NamespacedName)
 				return policyErr
@@ -105,16 +128,16 @@ func validateProcessSchema(policy *securityv1.KubeArmorPolicy, req ctrl.Request)
 			}
 		}
 	}
-	for _, matchDirectories := range policy.Spec.Process.MatchDirectories {
-		if policy.Spec.Action != "Allow" {
+	for _, matchDirectories := range spec.Process.MatchDirectories {
+		if spec.Action != "Allow" {
 			if matchDirectories.OwnerOnly {
 				policyErr = fmt.Errorf("ownerOnly works with the Allow action %v", req.NamespacedName)
 				return policyErr
 			}
 		}
 	}
-	for _, matchPatterns := range policy.Spec.Process.MatchPatterns {
-		if policy.Spec.Action != "Allow" {
+	for _, matchPatterns := range spec.Process.MatchPatterns {
+		if spec.Action != "Allow" {
 			if matchPatterns.OwnerOnly {
 				policyErr = fmt.Errorf("ownerOnly works with the Allow action %v", req.NamespacedName)
 				return policyErr
@@ -124,10 +147,10 @@ func validateProcessSchema(policy *securityv1.KubeArmorPolicy, req ctrl.Request)
 	return policyErr
 }
 
-func validateFileSchema(policy *securityv1.KubeArmorPolicy, req ctrl.Request) error {
+func validateFileSchema(spec *securityv1.KubeArmorPolicySpec, req ctrl.Request) error {
 	var policyErr error
-	for _, matchPaths := range policy.Spec.File.MatchPaths {
-		if policy.Spec.Action != "Allow" {
+	for _, matchPaths := range spec.File.MatchPaths {
+		if spec.Action != "Allow" {
 			if matchPaths.OwnerOnly {
 				policyErr = fmt.Errorf("ownerOnly works with the Allow action %v", req.NamespacedName)
 				return policyErr
@@ -142,16 +165,16 @@ func validateFileSchema(policy *securityv1.KubeArmorPolicy, req ctrl.Request) er
 			}
 		}
 	}
-	for _, matchDirectories := range policy.Spec.File.MatchDirectories {
-		if policy.Spec.Action != "Allow" {
+	for _, matchDirectories := range spec.File.MatchDirectories {
+		if spec.Action != "Allow" {
 			if matchDirectories.OwnerOnly {
 				policyErr = fmt.Errorf("ownerOnly works with the Allow action %v", req.NamespacedName)
 				return policyErr
 			}
 		}
 	}
-	for _, matchPatterns := range policy.Spec.File.MatchPatterns {
-		if policy.Spec.Action != "Allow" {
+	for _, matchPatterns := range spec.File.MatchPatterns {
+		if spec.Action != "Allow" {
 			if matchPatterns.OwnerOnly {
 				policyErr = fmt.Errorf("ownerOnly works with the Allow action %v", req.NamespacedName)
 				return policyErr
@@ -161,9 +184,9 @@ func validateFileSchema(policy *securityv1.KubeArmorPolicy, req ctrl.Request) er
 	return policyErr
 }
 
-func validateNetworkSchema(policy *securityv1.KubeArmorPolicy, req ctrl.Request) error {
+func validateNetworkSchema(spec *securityv1.KubeArmorPolicySpec, req ctrl.Request) error {
 	var policyErr error
-	for _, matchProtocols := range policy.Spec.Network.MatchProtocols {
+	for _, matchProtocols := range spec.Network.MatchProtocols {
 		for _, fromSource := range matchProtocols.FromSource {
 			if fromSource.Path != "" {
 				if fromSource.Recursive || !fromSource.Recursive {
@@ -176,9 +199,9 @@ func validateNetworkSchema(policy *securityv1.KubeArmorPolicy, req ctrl.Request)
 	return policyErr
 }
 
-func validateCapabilitiesSchema(policy *securityv1.KubeArmorPolicy, req ctrl.Request) error {
+func validateCapabilitiesSchema(spec *securityv1.KubeArmorPolicySpec, req ctrl.Request) error {
 	var policyErr error
-	for _, matchCapabilities := range policy.Spec.Capabilities.MatchCapabilities {
+	for _, matchCapabilities := range spec.Capabilities.MatchCapabilities {
 		for _, fromSource := range matchCapabilities.FromSource {
 			if fromSource.Path != "" {
 				if fromSource.Recursive || !fromSource.Recursive {
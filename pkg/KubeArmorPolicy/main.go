@@ -9,6 +9,7 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -16,6 +17,7 @@ import (
 
 	securityv1 "github.com/kubearmor/KubeArmor/pkg/KubeArmorPolicy/api/v1"
 	"github.com/kubearmor/KubeArmor/pkg/KubeArmorPolicy/controllers"
+	"github.com/kubearmor/KubeArmor/pkg/KubeArmorPolicy/webhooks"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -34,10 +36,23 @@ func init() {
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
+	var enableWebhook bool
+	var webhookEnforcer string
+	var webhookEnforcerPerPod bool
+	var webhookSeccomp bool
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&enableWebhook, "enable-webhook", false,
+		"Enable the mutating webhook that adds KubeArmor security profiles to pods at their creation. "+
+			"Enabling this requires the LSM given by webhook-enforcer on all nodes.")
+	flag.StringVar(&webhookEnforcer, "webhook-enforcer", "apparmor", "The LSM used by the mutating webhook (apparmor or selinux).")
+	flag.BoolVar(&webhookEnforcerPerPod, "webhook-enforcer-per-pod", false,
+		"Only mutate pods with the 'kubearmor-policy: enabled' annotation (the same as the enableEnforcerPerPod option of KubeArmor).")
+	flag.BoolVar(&webhookSeccomp, "webhook-seccomp", false,
+		"Add KubeArmor seccomp profiles to the containers of pods as well. "+
+			"Enabling this requires KubeArmor with the seccomp directory of the kubelet on all nodes.")
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
@@ -62,8 +77,27 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "KubeArmorPolicy")
 		os.Exit(1)
 	}
+	if err = (&controllers.KubeArmorClusterPolicyReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("KubeArmorClusterPolicy"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KubeArmorClusterPolicy")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
+	if enableWebhook {
+		mgr.GetWebhookServer().Register("/mutate-pods", &webhook.Admission{Handler: &webhooks.PodAnnotator{
+			Client:         mgr.GetClient(),
+			Log:            ctrl.Log.WithName("webhooks").WithName("Pod"),
+			Enforcer:       webhookEnforcer,
+			EnforcerPerPod: webhookEnforcerPerPod,
+			Seccomp:        webhookSeccomp,
+		}})
+		setupLog.Info("registered the mutating webhook", "enforcer", webhookEnforcer, "seccomp", webhookSeccomp)
+	}
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -0,0 +1,231 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/mutate-pods,mutating=true,failurePolicy=ignore,groups="",resources=pods,verbs=create,versions=v1,name=annotation.kubearmor.com
+
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+
+// PodAnnotator adds KubeArmor security profiles to pods at their creation
+type PodAnnotator struct {
+	Client client.Client
+	Log    logr.Logger
+
+	// apparmor or selinux
+	Enforcer string
+
+	// only handle pods with the 'kubearmor-policy: enabled' annotation
+	EnforcerPerPod bool
+
+	// add seccomp profiles as well
+	Seccomp bool
+
+	decoder *admission.Decoder
+}
+
+// Handle adds AppArmor annotations or SELinux options (and seccomp profiles) to the containers of a pod
+func (a *PodAnnotator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	pod := &corev1.Pod{}
+
+	if err := a.decoder.Decode(req, pod); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// the namespace of a pod may be empty at its creation
+	namespaceName := req.Namespace
+
+	if !a.isEnforced(ctx, namespaceName, pod) {
+		return admission.Allowed("not enforced by KubeArmor")
+	}
+
+	// mutate the raw object not to drop the fields unknown to the typed pod (e.g., seccompProfile)
+	rawPod := map[string]interface{}{}
+	if err := json.Unmarshal(req.Object.Raw, &rawPod); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	annotations, _ := getObject(rawPod, "metadata")["annotations"].(map[string]interface{})
+	spec := getObject(rawPod, "spec")
+
+	containers, _ := spec["containers"].([]interface{})
+
+	if a.Enforcer == "selinux" {
+		workloadName := a.getWorkloadName(ctx, namespaceName, pod)
+		if workloadName == "" {
+			return admission.Allowed("no workload to name SELinux types")
+		}
+
+		for _, obj := range containers {
+			container, ok := obj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			seLinuxOptions := getObject(getObject(container, "securityContext"), "seLinuxOptions")
+			if seLinuxType, _ := seLinuxOptions["type"].(string); seLinuxType == "" {
+				seLinuxOptions["type"] = "kubearmor-" + namespaceName + "-" + workloadName + "-" + getString(container, "name") + ".process"
+			}
+		}
+	} else { // apparmor
+		annotations = getObject(getObject(rawPod, "metadata"), "annotations")
+
+		for _, container := range pod.Spec.Containers {
+			if _, ok := annotations["container.apparmor.security.beta.kubernetes.io/"+container.Name]; !ok {
+				annotations["container.apparmor.security.beta.kubernetes.io/"+container.Name] = "localhost/kubearmor-" + namespaceName + "-" + container.Name
+			}
+		}
+	}
+
+	if a.Seccomp {
+		workloadName := a.getWorkloadName(ctx, namespaceName, pod)
+
+		// keep the seccomp profile of a pod if given
+		if !hasSeccompProfile(spec, annotations, "seccomp.security.alpha.kubernetes.io/pod") && workloadName != "" {
+			for _, obj := range containers {
+				container, ok := obj.(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				containerName := getString(container, "name")
+
+				if !hasSeccompProfile(container, annotations, "container.seccomp.security.alpha.kubernetes.io/"+containerName) {
+					// written by KubeArmor in the seccomp directory of the kubelet (Kubernetes 1.19 or later)
+					getObject(container, "securityContext")["seccompProfile"] = map[string]interface{}{
+						"type":             "Localhost",
+						"localhostProfile": "kubearmor/kubearmor-" + namespaceName + "-" + workloadName + "-" + containerName + ".json",
+					}
+				}
+			}
+		}
+	}
+
+	marshaledPod, err := json.Marshal(rawPod)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	podName := pod.Name
+	if podName == "" {
+		podName = pod.GenerateName
+	}
+
+	a.Log.Info("Injected KubeArmor security profiles", "namespace", namespaceName, "pod", podName)
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledPod)
+}
+
+// InjectDecoder injects the decoder
+func (a *PodAnnotator) InjectDecoder(d *admission.Decoder) error {
+	a.decoder = d
+	return nil
+}
+
+// isEnforced follows the exceptions that the KubeArmor daemon makes for pods
+func (a *PodAnnotator) isEnforced(ctx context.Context, namespaceName string, pod *corev1.Pod) bool {
+	policy, ok := pod.Annotations["kubearmor-policy"]
+	if !ok {
+		// pods inherit the annotation of their namespace
+		namespace := &corev1.Namespace{}
+		if err := a.Client.Get(ctx, types.NamespacedName{Name: namespaceName}, namespace); err == nil {
+			policy, ok = namespace.Annotations["kubearmor-policy"]
+		}
+	}
+
+	if ok {
+		if policy == "disabled" || policy == "audited" {
+			return false
+		}
+	} else if a.EnforcerPerPod {
+		return false
+	}
+
+	if namespaceName == "kube-system" {
+		// exception: kubernetes app
+		if _, ok := pod.Labels["k8s-app"]; ok {
+			return false
+		}
+
+		// exception: cilium-operator
+		if val, ok := pod.Labels["io.cilium/app"]; ok && val == "operator" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getWorkloadName returns the name of the workload controlling a pod (or the pod itself)
+func (a *PodAnnotator) getWorkloadName(ctx context.Context, namespaceName string, pod *corev1.Pod) string {
+	if len(pod.OwnerReferences) == 0 {
+		return pod.Name
+	}
+
+	owner := pod.OwnerReferences[0]
+
+	switch owner.Kind {
+	case "ReplicaSet":
+		rs := &appsv1.ReplicaSet{}
+		if err := a.Client.Get(ctx, types.NamespacedName{Namespace: namespaceName, Name: owner.Name}, rs); err == nil {
+			if len(rs.OwnerReferences) > 0 && rs.OwnerReferences[0].Kind == "Deployment" {
+				return rs.OwnerReferences[0].Name
+			}
+		}
+	case "Job":
+		job := &batchv1.Job{}
+		if err := a.Client.Get(ctx, types.NamespacedName{Namespace: namespaceName, Name: owner.Name}, job); err == nil {
+			if len(job.OwnerReferences) > 0 && job.OwnerReferences[0].Kind == "CronJob" {
+				return job.OwnerReferences[0].Name
+			}
+		}
+	}
+
+	return owner.Name
+}
+
+// getObject returns the object of a key in a raw object (added if not found)
+func getObject(obj map[string]interface{}, key string) map[string]interface{} {
+	if val, ok := obj[key].(map[string]interface{}); ok {
+		return val
+	}
+
+	val := map[string]interface{}{}
+	obj[key] = val
+
+	return val
+}
+
+// getString returns the string of a key in a raw object
+func getString(obj map[string]interface{}, key string) string {
+	val, _ := obj[key].(string)
+	return val
+}
+
+// hasSeccompProfile checks if a pod (or a container) already has its seccomp profile in the field or the annotation
+func hasSeccompProfile(obj, annotations map[string]interface{}, annotation string) bool {
+	if securityContext, ok := obj["securityContext"].(map[string]interface{}); ok {
+		if _, ok := securityContext["seccompProfile"]; ok {
+			return true
+		}
+	}
+
+	_, ok := annotations[annotation]
+	return ok
+}
@@ -0,0 +1,313 @@
+// Copyright 2021 Authors of KubeArmor
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// newPodAnnotator creates a pod annotator with a fake client holding the given objects
+func newPodAnnotator(t *testing.T, enforcer string, enforcerPerPod, seccomp bool, objs ...runtime.Object) *PodAnnotator {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("[FAIL] Failed to create a scheme (%s)", err.Error())
+	}
+
+	decoder, err := admission.NewDecoder(scheme)
+	if err != nil {
+		t.Fatalf("[FAIL] Failed to create a decoder (%s)", err.Error())
+	}
+
+	annotator := &PodAnnotator{
+		Client:         fake.NewFakeClientWithScheme(scheme, objs...),
+		Log:            logf.Log.WithName("test"),
+		Enforcer:       enforcer,
+		EnforcerPerPod: enforcerPerPod,
+		Seccomp:        seccomp,
+	}
+
+	if err := annotator.InjectDecoder(decoder); err != nil {
+		t.Fatalf("[FAIL] Failed to inject a decoder (%s)", err.Error())
+	}
+
+	return annotator
+}
+
+// handlePod sends a pod to the webhook and returns the patched pod
+func handlePod(t *testing.T, annotator *PodAnnotator, pod *corev1.Pod) map[string]interface{} {
+	raw, err := json.Marshal(pod)
+	if err != nil {
+		t.Fatalf("[FAIL] Failed to marshal a pod (%s)", err.Error())
+	}
+
+	return handleRawPod(t, annotator, pod.Namespace, raw)
+}
+
+// handleRawPod sends a raw pod to the webhook and returns the patched pod
+func handleRawPod(t *testing.T, annotator *PodAnnotator, namespaceName string, raw []byte) map[string]interface{} {
+	resp := annotator.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1beta1.AdmissionRequest{
+		Namespace: namespaceName,
+		Operation: admissionv1beta1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+	}})
+
+	if !resp.Allowed {
+		t.Fatalf("[FAIL] Denied a pod (%v)", resp.Result)
+	}
+
+	patched := raw
+
+	if len(resp.Patches) > 0 {
+		patchJSON, err := json.Marshal(resp.Patches)
+		if err != nil {
+			t.Fatalf("[FAIL] Failed to marshal patches (%s)", err.Error())
+		}
+
+		patch, err := jsonpatch.DecodePatch(patchJSON)
+		if err != nil {
+			t.Fatalf("[FAIL] Failed to decode patches (%s)", err.Error())
+		}
+
+		if patched, err = patch.Apply(raw); err != nil {
+			t.Fatalf("[FAIL] Failed to apply patches (%s)", err.Error())
+		}
+	}
+
+	res := map[string]interface{}{}
+	if err := json.Unmarshal(patched, &res); err != nil {
+		t.Fatalf("[FAIL] Failed to unmarshal a patched pod (%s)", err.Error())
+	}
+
+	return res
+}
+
+// getContainerField returns a field in the security context of the first container of a raw pod
+func getContainerField(pod map[string]interface{}, keys ...string) interface{} {
+	containers, _ := getObject(pod, "spec")["containers"].([]interface{})
+	if len(containers) == 0 {
+		return nil
+	}
+
+	obj, _ := containers[0].(map[string]interface{})
+	obj = getObject(obj, "securityContext")
+
+	for _, key := range keys[:len(keys)-1] {
+		obj = getObject(obj, key)
+	}
+
+	return obj[keys[len(keys)-1]]
+}
+
+func TestIsEnforced(t *testing.T) {
+	namespaces := []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "enabled", Annotations: map[string]string{"kubearmor-policy": "enabled"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "audited", Annotations: map[string]string{"kubearmor-policy": "audited"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+	}
+
+	cases := []struct {
+		name           string
+		namespace      string
+		annotations    map[string]string
+		labels         map[string]string
+		enforcerPerPod bool
+		enforced       bool
+	}{
+		{"a pod", "default", nil, nil, false, true},
+		{"a disabled pod", "default", map[string]string{"kubearmor-policy": "disabled"}, nil, false, false},
+		{"an audited pod", "default", map[string]string{"kubearmor-policy": "audited"}, nil, false, false},
+		{"a pod in an audited namespace", "audited", nil, nil, false, false},
+		{"an enabled pod in an audited namespace", "audited", map[string]string{"kubearmor-policy": "enabled"}, nil, false, true},
+		{"a pod in a namespace not found", "unknown", nil, nil, false, true},
+		{"a pod with per-pod enforcement", "default", nil, nil, true, false},
+		{"an enabled pod with per-pod enforcement", "default", map[string]string{"kubearmor-policy": "enabled"}, nil, true, true},
+		{"a pod in an enabled namespace with per-pod enforcement", "enabled", nil, nil, true, true},
+		{"a kubernetes app", "kube-system", nil, map[string]string{"k8s-app": "kube-dns"}, false, false},
+		{"the cilium operator", "kube-system", nil, map[string]string{"io.cilium/app": "operator"}, false, false},
+		{"a cilium agent", "kube-system", nil, map[string]string{"io.cilium/app": "agent"}, false, true},
+		{"a kubernetes app in another namespace", "default", nil, map[string]string{"k8s-app": "kube-dns"}, false, true},
+	}
+
+	for _, c := range cases {
+		annotator := newPodAnnotator(t, "apparmor", c.enforcerPerPod, false, namespaces...)
+
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: c.namespace, Annotations: c.annotations, Labels: c.labels}}
+
+		if enforced := annotator.isEnforced(context.Background(), c.namespace, pod); enforced != c.enforced {
+			t.Errorf("[FAIL] %s (expected: %v, got: %v)", c.name, c.enforced, enforced)
+			return
+		}
+	}
+
+	t.Log("[PASS] Followed the annotations and the exceptions of pods and namespaces")
+}
+
+func TestGetWorkloadName(t *testing.T) {
+	isController := true
+
+	ownerReference := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &isController}}
+	}
+
+	objs := []runtime.Object{
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "nginx-5d8f7b9c6", Namespace: "default", OwnerReferences: ownerReference("Deployment", "nginx")}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: "default"}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "backup-1627776000", Namespace: "default", OwnerReferences: ownerReference("CronJob", "backup")}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"}},
+	}
+
+	annotator := newPodAnnotator(t, "selinux", false, false, objs...)
+
+	cases := []struct {
+		name     string
+		owners   []metav1.OwnerReference
+		workload string
+	}{
+		{"a bare pod", nil, "pod"},
+		{"a pod of a deployment", ownerReference("ReplicaSet", "nginx-5d8f7b9c6"), "nginx"},
+		{"a pod of a replicaset", ownerReference("ReplicaSet", "standalone"), "standalone"},
+		{"a pod of a replicaset not found", ownerReference("ReplicaSet", "unknown"), "unknown"},
+		{"a pod of a cronjob", ownerReference("Job", "backup-1627776000"), "backup"},
+		{"a pod of a job", ownerReference("Job", "migrate"), "migrate"},
+		{"a pod of a statefulset", ownerReference("StatefulSet", "mysql"), "mysql"},
+	}
+
+	for _, c := range cases {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default", OwnerReferences: c.owners}}
+
+		if workload := annotator.getWorkloadName(context.Background(), "default", pod); workload != c.workload {
+			t.Errorf("[FAIL] %s (expected: %s, got: %s)", c.name, c.workload, workload)
+			return
+		}
+	}
+
+	t.Log("[PASS] Got the names of the workloads controlling pods")
+}
+
+func TestHandle(t *testing.T) {
+	newPod := func() *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}}},
+		}
+	}
+
+	// AppArmor
+
+	annotator := newPodAnnotator(t, "apparmor", false, false)
+
+	pod := handlePod(t, annotator, newPod())
+	annotations := getObject(getObject(pod, "metadata"), "annotations")
+
+	if val := annotations["container.apparmor.security.beta.kubernetes.io/nginx"]; val != "localhost/kubearmor-default-nginx" {
+		t.Errorf("[FAIL] Failed to add an AppArmor profile (%v)", val)
+		return
+	}
+
+	given := newPod()
+	given.Annotations = map[string]string{"container.apparmor.security.beta.kubernetes.io/nginx": "runtime/default"}
+
+	pod = handlePod(t, annotator, given)
+	annotations = getObject(getObject(pod, "metadata"), "annotations")
+
+	if val := annotations["container.apparmor.security.beta.kubernetes.io/nginx"]; val != "runtime/default" {
+		t.Errorf("[FAIL] Replaced the AppArmor profile of a pod (%v)", val)
+		return
+	}
+
+	t.Log("[PASS] Added AppArmor profiles and kept the given ones")
+
+	// SELinux (kubearmor-[namespace]-[workload]-[container].process, as the KubeArmor daemon patches workloads with)
+
+	isController := true
+
+	annotator = newPodAnnotator(t, "selinux", false, false, &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "nginx-5d8f7b9c6", Namespace: "default",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "nginx", Controller: &isController}}}})
+
+	given = newPod()
+	given.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "nginx-5d8f7b9c6", Controller: &isController}}
+
+	pod = handlePod(t, annotator, given)
+
+	if val := getContainerField(pod, "seLinuxOptions", "type"); val != "kubearmor-default-nginx-nginx.process" {
+		t.Errorf("[FAIL] Failed to add an SELinux type (%v)", val)
+		return
+	}
+
+	given = newPod()
+	given.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{SELinuxOptions: &corev1.SELinuxOptions{Type: "container_t"}}
+
+	pod = handlePod(t, annotator, given)
+
+	if val := getContainerField(pod, "seLinuxOptions", "type"); val != "container_t" {
+		t.Errorf("[FAIL] Replaced the SELinux type of a container (%v)", val)
+		return
+	}
+
+	t.Log("[PASS] Added SELinux types and kept the given ones")
+
+	// seccomp (the fields unknown to the pod of k8s.io/api v0.17 are kept as well)
+
+	annotator = newPodAnnotator(t, "apparmor", false, true)
+
+	pod = handlePod(t, annotator, newPod())
+
+	if val, _ := getContainerField(pod, "seccompProfile", "localhostProfile").(string); val != "kubearmor/kubearmor-default-pod-nginx.json" {
+		t.Errorf("[FAIL] Failed to add a seccomp profile (%v)", val)
+		return
+	}
+
+	given = newPod()
+	given.Annotations = map[string]string{"seccomp.security.alpha.kubernetes.io/pod": "runtime/default"}
+
+	pod = handlePod(t, annotator, given)
+
+	if val := getContainerField(pod, "seccompProfile"); val != nil {
+		t.Errorf("[FAIL] Replaced the seccomp profile of a pod (%v)", val)
+		return
+	}
+
+	// the seccompProfile field is not in the pod of k8s.io/api v0.17, so it is given in a raw pod
+	raw := []byte(`{"metadata":{"name":"pod","namespace":"default"},"spec":{"containers":[{"name":"nginx","image":"nginx",` +
+		`"securityContext":{"seccompProfile":{"type":"RuntimeDefault"}}}]}}`)
+
+	pod = handleRawPod(t, annotator, "default", raw)
+
+	if val, _ := getContainerField(pod, "seccompProfile", "type").(string); val != "RuntimeDefault" || getContainerField(pod, "seccompProfile", "localhostProfile") != nil {
+		t.Errorf("[FAIL] Replaced the seccomp profile of a container (%v)", getContainerField(pod, "seccompProfile"))
+		return
+	}
+
+	t.Log("[PASS] Added seccomp profiles and kept the given ones")
+
+	// not enforced
+
+	given = newPod()
+	given.Annotations = map[string]string{"kubearmor-policy": "disabled"}
+
+	pod = handlePod(t, annotator, given)
+	annotations = getObject(getObject(pod, "metadata"), "annotations")
+
+	if _, ok := annotations["container.apparmor.security.beta.kubernetes.io/nginx"]; ok {
+		t.Error("[FAIL] Added an AppArmor profile to a disabled pod")
+		return
+	}
+
+	t.Log("[PASS] Skipped a disabled pod")
+}